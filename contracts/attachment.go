@@ -52,6 +52,13 @@ func NewAttachmentFromFile(filePath string) (a Attachment, err error) {
 	return a, nil
 }
 
+// Clone returns attachment copy with its own content.
+func (a Attachment) Clone() Attachment {
+	a.Content = cloneBytes(a.Content)
+
+	return a
+}
+
 func (a Attachment) IsEmpty() bool {
 	return len(a.Content) == 0
 }
@@ -75,3 +82,13 @@ func (a Attachment) GetName() string {
 func (a Attachment) GetAttachMethod() AttachMethod {
 	return a.AttachMethod
 }
+
+// AttachmentFileName returns attachment file name, falling back to attachment name.
+// Inline attachments use it as content id, so html body refers them as cid:<file name>.
+func AttachmentFileName(att MessageAttachmentInterface) string {
+	if att.GetFileName() != "" {
+		return att.GetFileName()
+	}
+
+	return att.GetName()
+}
@@ -29,3 +29,18 @@ func (mal MessageAttachmentList) GetList() []MessageAttachmentInterface {
 func (mal MessageAttachmentList) IsEmpty() bool {
 	return len(mal) == 0
 }
+
+// Clone returns deep copy of the list.
+func (mal MessageAttachmentList) Clone() MessageAttachmentList {
+	if mal == nil {
+		return nil
+	}
+
+	c := make(MessageAttachmentList, 0, len(mal))
+
+	for _, a := range mal {
+		c = append(c, a.Clone())
+	}
+
+	return c
+}
@@ -144,3 +144,10 @@ func TestAttachment_GetContent(t *testing.T) {
 
 	assert.Equal(t, []byte("some content"), testAtt.GetContent())
 }
+
+func TestAttachmentFileName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "test.file", contracts.AttachmentFileName(testAtt))
+	assert.Equal(t, "test", contracts.AttachmentFileName(contracts.Attachment{Name: "test"}))
+}
@@ -18,7 +18,11 @@ type Message struct {
 
 	MimeType mime.Type
 	Subject  string
-	Content  []byte
+	// Content is a single message body of MimeType type.
+	Content []byte
+	// HTML and PlainText are multipart/alternative message bodies.
+	HTML      []byte
+	PlainText []byte
 
 	Attachments MessageAttachmentList
 }
@@ -107,26 +111,44 @@ func (mm *Message) SetMimeType(typ mime.Type) {
 
 func (mm *Message) SetHTML(msg []byte) error {
 	if msg == nil {
-		mm.emptyContent()
+		mm.emptyContent(mime.TextHTML)
 
 		return fmt.Errorf("%w: %s", errors.ErrEmptyData, "content")
 	}
 
 	mm.MimeType = mime.TextHTML
-	mm.Content = msg
+	mm.HTML = msg
 
 	return nil
 }
 
 func (mm *Message) SetPlainText(msg []byte) error {
 	if msg == nil {
-		mm.emptyContent()
+		mm.emptyContent(mime.TextPlain)
 
 		return fmt.Errorf("%w: %s", errors.ErrEmptyData, "content")
 	}
 
-	mm.MimeType = mime.TextPlain
-	mm.Content = msg
+	mm.PlainText = msg
+
+	if len(mm.GetHTML()) == 0 {
+		mm.MimeType = mime.TextPlain
+	}
+
+	return nil
+}
+
+// GeneratePlainText fills plain text part from html one if plain text is not set.
+func (mm *Message) GeneratePlainText() error {
+	if len(mm.GetPlainText()) != 0 {
+		return nil
+	}
+
+	if len(mm.GetHTML()) == 0 {
+		return fmt.Errorf("%w: %s", errors.ErrEmptyData, "html content")
+	}
+
+	mm.PlainText = HTMLToPlainText(mm.GetHTML())
 
 	return nil
 }
@@ -157,6 +179,20 @@ func (mm *Message) AddAttachments(files ...MessageAttachmentInterface) error {
 	return nil
 }
 
+// Clone returns deep copy of the message, so changes of the copy don't affect the original.
+func (mm Message) Clone() MessageInterface {
+	c := mm
+	c.To = cloneAddressList(mm.To)
+	c.Cc = cloneAddressList(mm.Cc)
+	c.Bcc = cloneAddressList(mm.Bcc)
+	c.Content = cloneBytes(mm.Content)
+	c.HTML = cloneBytes(mm.HTML)
+	c.PlainText = cloneBytes(mm.PlainText)
+	c.Attachments = mm.Attachments.Clone()
+
+	return &c
+}
+
 func (mm Message) GetAttachments() MessageAttachmentListInterface {
 	return mm.Attachments
 }
@@ -185,8 +221,48 @@ func (mm Message) GetMimeType() mime.Type {
 	return mm.MimeType
 }
 
+// GetBody returns message body of GetMimeType type.
 func (mm Message) GetBody() []byte {
-	return mm.Content
+	if mm.MimeType == mime.TextHTML && len(mm.GetHTML()) != 0 {
+		return mm.GetHTML()
+	}
+
+	if len(mm.GetPlainText()) != 0 {
+		return mm.GetPlainText()
+	}
+
+	return mm.GetHTML()
+}
+
+// GetHTML returns html part of the message.
+func (mm Message) GetHTML() []byte {
+	if len(mm.HTML) != 0 {
+		return mm.HTML
+	}
+
+	if mm.MimeType == mime.TextHTML {
+		return mm.Content
+	}
+
+	return nil
+}
+
+// GetPlainText returns plain text part of the message.
+func (mm Message) GetPlainText() []byte {
+	if len(mm.PlainText) != 0 {
+		return mm.PlainText
+	}
+
+	if mm.MimeType != mime.TextHTML {
+		return mm.Content
+	}
+
+	return nil
+}
+
+// IsAlternative reports whether message has both html and plain text parts.
+func (mm Message) IsAlternative() bool {
+	return len(mm.GetHTML()) != 0 && len(mm.GetPlainText()) != 0
 }
 
 func (mm Message) GetSubject() string {
@@ -218,7 +294,40 @@ body:
 	)
 }
 
-func (mm *Message) emptyContent() {
-	mm.MimeType = ""
-	mm.Content = nil
+// emptyContent removes message part of typ type and fixes message mime type.
+func (mm *Message) emptyContent(typ mime.Type) {
+	if typ == mime.TextHTML {
+		mm.HTML = nil
+	} else {
+		mm.PlainText = nil
+	}
+
+	if mm.MimeType == typ || (typ == mime.TextPlain && mm.MimeType.IsEmpty()) {
+		mm.Content = nil
+	}
+
+	switch {
+	case len(mm.GetHTML()) != 0:
+		mm.MimeType = mime.TextHTML
+	case len(mm.GetPlainText()) != 0:
+		mm.MimeType = mime.TextPlain
+	default:
+		mm.MimeType = ""
+	}
+}
+
+func cloneAddressList(list mailing.MailAddressList) mailing.MailAddressList {
+	if list == nil {
+		return nil
+	}
+
+	return append(make(mailing.MailAddressList, 0, len(list)), list...)
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(b)), b...)
 }
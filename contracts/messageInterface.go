@@ -18,6 +18,7 @@ type MessageInterface interface {
 	SetMimeType(typ mime.Type)
 	SetHTML(msg []byte) error
 	SetPlainText(msg []byte) error
+	GeneratePlainText() error
 	AddAttachment(file MessageAttachmentInterface) error
 	AddAttachments(files ...MessageAttachmentInterface) error
 
@@ -29,7 +30,11 @@ type MessageInterface interface {
 	GetSubject() string
 	GetMimeType() mime.Type
 	GetBody() []byte
+	GetHTML() []byte
+	GetPlainText() []byte
+	IsAlternative() bool
 	GetAttachments() MessageAttachmentListInterface
 
 	String() string
+	Clone() MessageInterface
 }
@@ -610,3 +610,170 @@ body:
 
 	assert.Equal(t, expString, msg.String())
 }
+
+func TestMessage_GetBodyParts(t *testing.T) {
+	type expStruct struct {
+		mime        mime.Type
+		body        []byte
+		html        []byte
+		plainText   []byte
+		alternative bool
+	}
+	type testCase struct {
+		name string
+		in   func() contracts.Message
+		exp  expStruct
+	}
+
+	tcs := []testCase{
+		{
+			name: "single html content",
+			in: func() contracts.Message {
+				return contracts.Message{MimeType: mime.TextHTML, Content: []byte("<p>html</p>")}
+			},
+			exp: expStruct{mime: mime.TextHTML, body: []byte("<p>html</p>"), html: []byte("<p>html</p>")},
+		},
+		{
+			name: "single content without mime type",
+			in: func() contracts.Message {
+				return contracts.Message{Content: []byte("text")}
+			},
+			exp: expStruct{body: []byte("text"), plainText: []byte("text")},
+		},
+		{
+			name: "html and plain text set",
+			in: func() contracts.Message {
+				msg := contracts.Message{}
+				_ = msg.SetPlainText([]byte("text"))
+				_ = msg.SetHTML([]byte("<p>html</p>"))
+
+				return msg
+			},
+			exp: expStruct{mime: mime.TextHTML, body: []byte("<p>html</p>"), html: []byte("<p>html</p>"), plainText: []byte("text"), alternative: true},
+		},
+		{
+			name: "plain text added to single html content",
+			in: func() contracts.Message {
+				msg := contracts.Message{MimeType: mime.TextHTML, Content: []byte("<p>html</p>")}
+				_ = msg.SetPlainText([]byte("text"))
+
+				return msg
+			},
+			exp: expStruct{mime: mime.TextHTML, body: []byte("<p>html</p>"), html: []byte("<p>html</p>"), plainText: []byte("text"), alternative: true},
+		},
+		{
+			name: "html part removed",
+			in: func() contracts.Message {
+				msg := contracts.Message{}
+				_ = msg.SetHTML([]byte("<p>html</p>"))
+				_ = msg.SetPlainText([]byte("text"))
+				_ = msg.SetHTML(nil)
+
+				return msg
+			},
+			exp: expStruct{mime: mime.TextPlain, body: []byte("text"), plainText: []byte("text")},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg := tc.in()
+
+			assert.Equal(t, tc.exp.mime, msg.GetMimeType())
+			assert.Equal(t, tc.exp.body, msg.GetBody())
+			assert.Equal(t, tc.exp.html, msg.GetHTML())
+			assert.Equal(t, tc.exp.plainText, msg.GetPlainText())
+			assert.Equal(t, tc.exp.alternative, msg.IsAlternative())
+		})
+	}
+}
+
+func TestMessage_GeneratePlainText(t *testing.T) {
+	type testCase struct {
+		name string
+		in   contracts.Message
+		exp  []byte
+		err  error
+	}
+
+	tcs := []testCase{
+		{
+			name: "generated from html",
+			in:   contracts.Message{HTML: []byte("<p>Hello, <b>World</b>!</p>")},
+			exp:  []byte("Hello, World!"),
+		},
+		{
+			name: "plain text is kept",
+			in:   contracts.Message{HTML: []byte("<p>html</p>"), PlainText: []byte("text")},
+			exp:  []byte("text"),
+		},
+		{
+			name: "no html",
+			in:   contracts.Message{},
+			exp:  nil,
+			err:  errors.ErrEmptyData,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.in.GeneratePlainText()
+			if tc.err != nil {
+				if !assert.ErrorIs(t, err, tc.err) {
+					t.FailNow()
+				}
+			} else {
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+			}
+
+			assert.Equal(t, tc.exp, tc.in.GetPlainText())
+		})
+	}
+}
+
+func TestMessage_Clone(t *testing.T) {
+	t.Parallel()
+
+	msg := contracts.Message{
+		From:        mailing.MailAddress{Email: "from@spacetab.io", Name: "From"},
+		To:          mailing.MailAddressList{{Email: "to@spacetab.io", Name: "To"}},
+		Cc:          mailing.MailAddressList{{Email: "cc@spacetab.io", Name: "Cc"}},
+		Bcc:         mailing.MailAddressList{{Email: "bcc@spacetab.io", Name: "Bcc"}},
+		Subject:     "Test email",
+		HTML:        []byte("<p>test email content</p>"),
+		PlainText:   []byte("test email content"),
+		Attachments: contracts.MessageAttachmentList{{MimeType: "text/plain", AttachMethod: contracts.AttachMethodFile, Filename: "test.txt", Content: []byte("content")}},
+	}
+	orig := msg.String()
+
+	clone, ok := msg.Clone().(*contracts.Message)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, msg, *clone)
+
+	_ = clone.SetFrom(mailing.MailAddress{Email: "other@spacetab.io"})
+	_ = clone.SetSubject("[test] Test email")
+	_ = clone.SetTo(mailing.MailAddress{Email: "other@spacetab.io"})
+	clone.Cc[0].Email = "other@spacetab.io"
+	clone.Bcc[0].Email = "other@spacetab.io"
+	clone.HTML[1] = 'b'
+	clone.PlainText[0] = 'T'
+	clone.Attachments[0].Content[0] = 'C'
+
+	assert.Equal(t, orig, msg.String())
+	assert.Equal(t, []byte("content"), msg.Attachments[0].Content)
+}
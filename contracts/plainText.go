@@ -0,0 +1,175 @@
+package contracts
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLToPlainText renders html document as readable plain text:
+// blocks are separated by line breaks, links keep their urls and
+// invisible elements (head, script, style) are skipped.
+func HTMLToPlainText(htmlContent []byte) []byte {
+	var (
+		tokenizer = html.NewTokenizer(bytes.NewReader(htmlContent))
+		out       = &plainTextWriter{}
+		skip      = 0
+		hrefs     = make([]string, 0)
+	)
+
+	for {
+		tt := tokenizer.Next()
+
+		switch tt {
+		case html.ErrorToken:
+			return out.bytes()
+		case html.TextToken:
+			if skip == 0 {
+				out.writeText(string(tokenizer.Text()))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			tag := string(name)
+
+			switch tag {
+			case "head", "script", "style", "title":
+				if tt == html.StartTagToken {
+					skip++
+				}
+			case "br":
+				out.lineBreak()
+			case "li":
+				out.blockBreak(false)
+				out.writeText("- ")
+			case "a":
+				href := ""
+
+				for hasAttr {
+					var key, val []byte
+
+					key, val, hasAttr = tokenizer.TagAttr()
+					if string(key) == "href" {
+						href = string(val)
+					}
+				}
+
+				hrefs = append(hrefs, href)
+			default:
+				if isBlockTag(tag) {
+					out.blockBreak(isParagraphTag(tag))
+				}
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+
+			switch tag {
+			case "head", "script", "style", "title":
+				if skip > 0 {
+					skip--
+				}
+			case "a":
+				if len(hrefs) == 0 {
+					continue
+				}
+
+				href := hrefs[len(hrefs)-1]
+				hrefs = hrefs[:len(hrefs)-1]
+
+				if href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(href, "mailto:") && out.lastText != href {
+					out.writeText(" (" + href + ")")
+				}
+			default:
+				if isBlockTag(tag) {
+					out.blockBreak(isParagraphTag(tag))
+				}
+			}
+		case html.CommentToken, html.DoctypeToken:
+		}
+	}
+}
+
+func isBlockTag(tag string) bool {
+	switch tag {
+	case "p", "div", "section", "article", "header", "footer", "table", "tr", "ul", "ol", "li",
+		"h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "hr":
+		return true
+	}
+
+	return false
+}
+
+func isParagraphTag(tag string) bool {
+	switch tag {
+	case "p", "h1", "h2", "h3", "h4", "h5", "h6", "table", "ul", "ol", "blockquote", "hr":
+		return true
+	}
+
+	return false
+}
+
+// plainTextWriter collapses html whitespaces and limits consecutive line breaks.
+type plainTextWriter struct {
+	buf       strings.Builder
+	newLines  int
+	pendingWS bool
+	lastText  string
+}
+
+func (w *plainTextWriter) writeText(text string) {
+	fields := strings.Fields(text)
+
+	if len(fields) == 0 {
+		if text != "" {
+			w.pendingWS = true
+		}
+
+		return
+	}
+
+	if w.buf.Len() != 0 && w.newLines == 0 && (w.pendingWS || startsWithSpace(text)) {
+		w.buf.WriteByte(' ')
+	}
+
+	joined := strings.Join(fields, " ")
+	w.buf.WriteString(joined)
+	w.lastText = joined
+	w.newLines = 0
+	w.pendingWS = endsWithSpace(text)
+}
+
+func (w *plainTextWriter) lineBreak() {
+	if w.buf.Len() == 0 {
+		return
+	}
+
+	w.buf.WriteByte('\n')
+	w.newLines++
+	w.pendingWS = false
+}
+
+// blockBreak starts new line, separating paragraphs with empty line.
+func (w *plainTextWriter) blockBreak(paragraph bool) {
+	limit := 1
+	if paragraph {
+		limit = 2 // nolint: gomnd
+	}
+
+	for w.buf.Len() != 0 && w.newLines < limit {
+		w.lineBreak()
+	}
+}
+
+func (w *plainTextWriter) bytes() []byte {
+	return []byte(strings.TrimSpace(w.buf.String()))
+}
+
+func startsWithSpace(s string) bool {
+	return strings.TrimLeftFunc(s, unicode.IsSpace) != s
+}
+
+func endsWithSpace(s string) bool {
+	return strings.TrimRightFunc(s, unicode.IsSpace) != s
+}
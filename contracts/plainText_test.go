@@ -0,0 +1,55 @@
+package contracts_test
+
+import (
+	"testing"
+
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToPlainText(t *testing.T) {
+	type testCase struct {
+		name string
+		in   string
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "inline text",
+			in:   "<span>Hello,</span> <b>World</b>&nbsp;&amp; all",
+			exp:  "Hello, World & all",
+		},
+		{
+			name: "paragraphs and line breaks",
+			in:   "<html><head><title>Title</title><style>p {}</style></head><body><h1>Header</h1><p>first<br/>line</p><p>second</p></body></html>",
+			exp:  "Header\n\nfirst\nline\n\nsecond",
+		},
+		{
+			name: "lists",
+			in:   "<ul><li>one</li><li>two</li></ul>",
+			exp:  "- one\n- two",
+		},
+		{
+			name: "links",
+			in:   `<p>Go to <a href="https://spacetab.io">our site</a> or <a href="https://spacetab.io">https://spacetab.io</a></p>`,
+			exp:  "Go to our site (https://spacetab.io) or https://spacetab.io",
+		},
+		{
+			name: "scripts are skipped",
+			in:   "<div>text<script>alert('hi')</script></div>",
+			exp:  "text",
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.exp, string(contracts.HTMLToPlainText([]byte(tc.in))))
+		})
+	}
+}
@@ -10,3 +10,10 @@ type ProviderInterface interface {
 	Name() mailing.MailProviderName
 	Send(ctx context.Context, msg MessageInterface) error
 }
+
+// ResultProviderInterface is a provider reporting provider message id and recipients statuses.
+type ResultProviderInterface interface {
+	ProviderInterface
+
+	SendWithResult(ctx context.Context, msg MessageInterface) (SendResult, error)
+}
@@ -0,0 +1,39 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+// RecipientStatus is a delivery status of a single recipient reported by provider.
+type RecipientStatus struct {
+	Email     string
+	MessageID string
+	Status    string
+	Reason    string
+}
+
+// SendResult describes message accepted by provider.
+type SendResult struct {
+	Provider  mailing.MailProviderName
+	MessageID string
+	Accepted  []RecipientStatus
+	Rejected  []RecipientStatus
+	SentAt    time.Time
+}
+
+// SendWithResult sends message with provider and returns its send result. Result of providers
+// not implementing ResultProviderInterface contains provider name and send time only.
+func SendWithResult(ctx context.Context, provider ProviderInterface, msg MessageInterface) (SendResult, error) {
+	if rp, ok := provider.(ResultProviderInterface); ok {
+		return rp.SendWithResult(ctx, msg)
+	}
+
+	if err := provider.Send(ctx, msg); err != nil {
+		return SendResult{Provider: provider.Name()}, err
+	}
+
+	return SendResult{Provider: provider.Name(), SentAt: time.Now()}, nil
+}
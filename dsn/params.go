@@ -0,0 +1,101 @@
+package dsn
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+// Params are dsn query parameters. Getters mark parameters as known and record malformed values,
+// both are reported by Parse after scheme Parse function returns.
+type Params struct {
+	values url.Values
+	known  map[string]bool
+	errs   []error
+}
+
+func newParams(values url.Values) *Params {
+	return &Params{values: values, known: make(map[string]bool)}
+}
+
+// String returns parameter value, empty if it is not set.
+func (p *Params) String(key string) string {
+	p.known[key] = true
+
+	return p.values.Get(key)
+}
+
+// Strings returns all values of repeated parameter.
+func (p *Params) Strings(key string) []string {
+	p.known[key] = true
+
+	return p.values[key]
+}
+
+// Bool returns parameter value parsed with strconv.ParseBool, false if it is not set.
+func (p *Params) Bool(key string) bool {
+	v := p.String(key)
+	if v == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%w: %s parameter %q is not a bool", mailErrors.ErrInvalidDSN, key, v))
+	}
+
+	return b
+}
+
+// OptionalBool returns parameter value parsed with strconv.ParseBool, nil if it is not set.
+func (p *Params) OptionalBool(key string) *bool {
+	if p.String(key) == "" {
+		return nil
+	}
+
+	b := p.Bool(key)
+
+	return &b
+}
+
+// Duration returns parameter value parsed with time.ParseDuration, zero if it is not set.
+func (p *Params) Duration(key string) time.Duration {
+	v := p.String(key)
+	if v == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%w: %s parameter %q is not a duration", mailErrors.ErrInvalidDSN, key, v))
+	}
+
+	return d
+}
+
+// err returns the first malformed value error or unknown parameters error.
+func (p *Params) err() error {
+	if len(p.errs) != 0 {
+		return p.errs[0]
+	}
+
+	unknown := make([]string, 0)
+
+	for key := range p.values {
+		if !p.known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+
+	return fmt.Errorf("%w: unknown parameters %q", mailErrors.ErrInvalidDSN, unknown)
+}
@@ -0,0 +1,22 @@
+package eml
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const messageIDRandomBytes = 8
+
+// NewMessageID generates unique message id (without angle brackets) for domain.
+func NewMessageID(domain string) string {
+	if domain == "" {
+		domain = "localhost"
+	}
+
+	b := make([]byte, messageIDRandomBytes)
+	_, _ = rand.Read(b)
+
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + "." + hex.EncodeToString(b) + "@" + domain // nolint: gomnd
+}
@@ -0,0 +1,251 @@
+package eml
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"golang.org/x/net/html/charset"
+)
+
+// Unmarshal parses message in message/rfc822 format.
+func Unmarshal(data []byte) (contracts.Message, error) {
+	return Read(bytes.NewReader(data))
+}
+
+// Read parses message in message/rfc822 format. The first text/plain and text/html parts
+// which are not attachments become message PlainText and HTML (converted to utf-8 with LF line breaks),
+// other parts become attachments. Content id of inline attachment is used as its file name,
+// so html references "cid:<file name>" keep working when message is sent again.
+func Read(r io.Reader) (contracts.Message, error) {
+	raw, err := mail.ReadMessage(r)
+	if err != nil {
+		return contracts.Message{}, fmt.Errorf("eml read error: %w", err)
+	}
+
+	mr := &messageReader{dec: &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}}
+
+	if err := mr.readHeaders(raw.Header); err != nil {
+		return contracts.Message{}, fmt.Errorf("eml read error: %w", err)
+	}
+
+	if err := mr.readPart(textproto.MIMEHeader(raw.Header), raw.Body, ""); err != nil {
+		return contracts.Message{}, fmt.Errorf("eml read error: %w", err)
+	}
+
+	if mr.html != nil {
+		_ = mr.msg.SetHTML(mr.html)
+	}
+
+	if mr.plainText != nil {
+		_ = mr.msg.SetPlainText(mr.plainText)
+	}
+
+	return mr.msg, nil
+}
+
+type messageReader struct {
+	dec       *mime.WordDecoder
+	msg       contracts.Message
+	html      []byte
+	plainText []byte
+	parts     int
+}
+
+func (mr *messageReader) readHeaders(header mail.Header) error {
+	ap := &mail.AddressParser{WordDecoder: mr.dec}
+
+	from, err := mr.parseAddress(ap, header.Get("From"))
+	if err != nil {
+		return fmt.Errorf("from header parse error: %w", err)
+	}
+
+	replyTo, err := mr.parseAddress(ap, header.Get("Reply-To"))
+	if err != nil {
+		return fmt.Errorf("reply-to header parse error: %w", err)
+	}
+
+	mr.msg.From, mr.msg.ReplyTo = from, replyTo
+
+	lists := []struct {
+		key  string
+		list *mailing.MailAddressList
+	}{{"To", &mr.msg.To}, {"Cc", &mr.msg.Cc}, {"Bcc", &mr.msg.Bcc}}
+
+	for _, l := range lists {
+		if *l.list, err = mr.parseAddressList(ap, header.Get(l.key)); err != nil {
+			return fmt.Errorf("%s header parse error: %w", strings.ToLower(l.key), err)
+		}
+	}
+
+	mr.msg.Subject = mr.decodeHeader(header.Get("Subject"))
+
+	return nil
+}
+
+func (mr *messageReader) parseAddress(ap *mail.AddressParser, value string) (mailing.MailAddress, error) {
+	list, err := mr.parseAddressList(ap, value)
+	if err != nil || len(list) == 0 {
+		return mailing.MailAddress{}, err
+	}
+
+	return list[0], nil
+}
+
+func (mr *messageReader) parseAddressList(ap *mail.AddressParser, value string) (mailing.MailAddressList, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	addrs, err := ap.ParseList(value)
+	if err != nil {
+		return nil, fmt.Errorf("address list parse error: %w", err)
+	}
+
+	list := make(mailing.MailAddressList, 0, len(addrs))
+	for _, addr := range addrs {
+		list = append(list, mailing.MailAddress{Email: addr.Address, Name: addr.Name})
+	}
+
+	return list, nil
+}
+
+// decodeHeader decodes RFC 2047 encoded words, returning value as is if it can't be decoded.
+func (mr *messageReader) decodeHeader(value string) string {
+	decoded, err := mr.dec.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+
+	return decoded
+}
+
+// readPart reads part of parent multipart container of parentType type (empty for message body).
+func (mr *messageReader) readPart(header textproto.MIMEHeader, body io.Reader, parentType string) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return mr.readMultipart(body, mediaType, params["boundary"])
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("%s part read error: %w", mediaType, err)
+	}
+
+	disposition, dParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	fileName := mr.decodeHeader(dParams["filename"])
+
+	if fileName == "" {
+		fileName = mr.decodeHeader(params["name"])
+	}
+
+	isAttachment := disposition == "attachment" || fileName != ""
+
+	switch {
+	case !isAttachment && mediaType == "text/html" && mr.html == nil:
+		mr.html = decodeText(content, params["charset"])
+	case !isAttachment && mediaType == "text/plain" && mr.plainText == nil:
+		mr.plainText = decodeText(content, params["charset"])
+	default:
+		mr.addAttachment(header, mediaType, disposition, fileName, content, parentType)
+	}
+
+	return nil
+}
+
+func (mr *messageReader) readMultipart(body io.Reader, mediaType string, boundary string) error {
+	if boundary == "" {
+		return fmt.Errorf("%s boundary is missing", mediaType) // nolint: goerr113
+	}
+
+	parts := multipart.NewReader(body, boundary)
+
+	for {
+		// raw part keeps quoted-printable content and its header, transfer encodings are decoded in readPart
+		p, err := parts.NextRawPart()
+		if err == io.EOF { // nolint: errorlint
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s part read error: %w", mediaType, err)
+		}
+
+		if err := mr.readPart(p.Header, p, mediaType); err != nil {
+			return err
+		}
+	}
+}
+
+func (mr *messageReader) addAttachment(header textproto.MIMEHeader, mediaType, disposition, fileName string, content []byte, parentType string) {
+	mr.parts++
+
+	if fileName == "" {
+		fileName = "part" + strconv.Itoa(mr.parts)
+
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) != 0 {
+			fileName += exts[0]
+		}
+	}
+
+	contentID := strings.Trim(header.Get("Content-ID"), "<> ")
+	isInline := disposition == "inline" || (disposition == "" && contentID != "" && parentType == "multipart/related")
+
+	att := contracts.Attachment{
+		MimeType:     mediaType,
+		AttachMethod: contracts.AttachMethodFile,
+		Filename:     fileName,
+		Name:         strings.TrimSuffix(fileName, filepath.Ext(fileName)),
+		Extension:    strings.TrimPrefix(filepath.Ext(fileName), "."),
+		Content:      content,
+	}
+
+	if isInline {
+		att.AttachMethod = contracts.AttachMethodInline
+
+		if contentID != "" {
+			att.Filename = contentID
+		}
+	}
+
+	mr.msg.Attachments = append(mr.msg.Attachments, att)
+}
+
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// decodeText converts text into utf-8 with LF line breaks. Text in unknown charset is kept as is.
+func decodeText(content []byte, label string) []byte {
+	if label != "" && !strings.EqualFold(label, "utf-8") && !strings.EqualFold(label, "us-ascii") {
+		if r, err := charset.NewReaderLabel(label, bytes.NewReader(content)); err == nil {
+			if decoded, err := io.ReadAll(r); err == nil {
+				content = decoded
+			}
+		}
+	}
+
+	return bytes.ReplaceAll(content, []byte(crlf), []byte("\n"))
+}
@@ -0,0 +1,132 @@
+package eml_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	cfgmime "github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead_RoundTrip(t *testing.T) {
+	msgs := newTestMessages()
+
+	withParts := func(name string, html, plainText string, attachments contracts.MessageAttachmentList) contracts.Message {
+		msg := msgs[name]
+		msg.Content = nil
+		msg.HTML = nil
+		msg.PlainText = nil
+
+		if html != "" {
+			_ = msg.SetHTML([]byte(html))
+		}
+
+		if plainText != "" {
+			_ = msg.SetPlainText([]byte(plainText))
+		}
+
+		msg.Attachments = attachments
+
+		return msg
+	}
+
+	exp := map[string]contracts.Message{
+		"plain":       withParts("plain", "", "test email content\nsecond line", nil),
+		"html":        withParts("html", "<p>test email content</p>", "", nil),
+		"alternative": withParts("alternative", `<p>test email content <img src="cid:logo.png"></p>`, "test email content", nil),
+		"attachments": withParts("attachments", `<p>test email content <img src="cid:logo.png"></p>`, "test email content", contracts.MessageAttachmentList{
+			{MimeType: "image/png", AttachMethod: contracts.AttachMethodInline, Filename: "logo.png", Name: "logo", Extension: "png", Content: []byte("png")},
+			{MimeType: "application/pdf", AttachMethod: contracts.AttachMethodFile, Filename: "report.pdf", Name: "report", Extension: "pdf", Content: bytes.Repeat([]byte("pdf"), 30)},
+		}),
+		"unicode": withParts("unicode", "", "Привет, мир", contracts.MessageAttachmentList{
+			{MimeType: "text/plain", AttachMethod: contracts.AttachMethodFile, Filename: "отчёт.txt", Name: "отчёт", Extension: "txt", Content: []byte("отчёт")},
+		}),
+	}
+
+	t.Parallel()
+
+	for name, msg := range msgs {
+		name, msg := name, msg
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := testOptions
+			opts.IncludeBcc = true
+
+			raw, err := eml.Marshal(&msg, opts)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			got, err := eml.Unmarshal(raw)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, exp[name], got)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("testdata", "thunderbird.eml"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	defer f.Close()
+
+	got, err := eml.Read(f)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	exp := contracts.Message{
+		From:      mailing.MailAddress{Email: "ivan@example.com", Name: "Иван Петров"},
+		ReplyTo:   mailing.MailAddress{Email: "reports@example.com"},
+		To:        mailing.MailAddressList{{Email: "team@spacetab.io", Name: "Team"}, {Email: "boss@spacetab.io"}},
+		Cc:        mailing.MailAddressList{{Email: "archive@spacetab.io", Name: "Архив"}},
+		MimeType:  cfgmime.TextHTML,
+		Subject:   "Отчёт за октябрь",
+		HTML:      []byte(`<html><body><p>Report <img src="cid:part1.abc@example.com"></p></body></html>`),
+		PlainText: []byte("Добрый день!\nОтчёт во вложении."),
+		Attachments: contracts.MessageAttachmentList{
+			{MimeType: "image/png", AttachMethod: contracts.AttachMethodInline, Filename: "part1.abc@example.com", Name: "chart", Extension: "png", Content: []byte("\x89PNG fake")},
+			{MimeType: "application/pdf", AttachMethod: contracts.AttachMethodFile, Filename: "отчёт.pdf", Name: "отчёт", Extension: "pdf", Content: bytes.Repeat([]byte("%PDF-1.4 fake report content"), 4)},
+		},
+	}
+
+	assert.Equal(t, exp, got)
+}
+
+func TestRead_Errors(t *testing.T) {
+	type testCase struct {
+		name string
+		in   string
+	}
+
+	tcs := []testCase{
+		{name: "no headers", in: "just text"},
+		{name: "broken address", in: "From: <broken\r\n\r\nbody"},
+		{name: "missing boundary", in: "Content-Type: multipart/mixed\r\n\r\nbody"},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := eml.Unmarshal([]byte(tc.in))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package eml
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" // nolint: gosec
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	customMime "github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+)
+
+const (
+	crlf = "\r\n"
+	// maxLineLength is recommended line length limit without CRLF (RFC 5322, section 2.1.1).
+	maxLineLength = 76
+	// max7bitLineLength is line length limit of 7bit transfer encoding without CRLF.
+	max7bitLineLength = 998
+)
+
+// Options configures message serialization.
+type Options struct {
+	// MessageID is Message-ID header value without angle brackets. Generated with NewMessageID if empty.
+	MessageID string
+	// Date is Date header value. Current time is used if it is zero.
+	Date time.Time
+	// IncludeBcc adds Bcc header, which is omitted by default as MTA would do on delivery.
+	IncludeBcc bool
+	// Headers are extra message headers written after standard ones.
+	Headers map[string]string
+}
+
+// Marshal returns message in message/rfc822 format.
+func Marshal(msg contracts.MessageInterface, opts Options) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	if err := Write(buf, msg, opts); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Write writes message in message/rfc822 format: headers with RFC 2047 encoded words and MIME tree
+// multipart/mixed (file attachments) > multipart/related (inline attachments) > multipart/alternative
+// (html and plain text parts), where containers without parts are omitted. Multipart boundaries
+// are derived from message id, so the same message id and date give the same output.
+func Write(w io.Writer, msg contracts.MessageInterface, opts Options) error {
+	if opts.MessageID == "" {
+		opts.MessageID = NewMessageID(msg.GetFrom().GetDomain())
+	}
+
+	if opts.Date.IsZero() {
+		opts.Date = time.Now()
+	}
+
+	bw := bufio.NewWriter(w)
+	mw := &messageWriter{w: bw, messageID: opts.MessageID}
+
+	mw.writeHeaders(msg, opts)
+
+	if err := mw.writeBody(msg); err != nil {
+		return fmt.Errorf("eml write error: %w", err)
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("eml write error: %w", err)
+	}
+
+	return nil
+}
+
+// messageWriter relies on bufio.Writer keeping the first write error, so only its Flush result is checked.
+type messageWriter struct {
+	w          *bufio.Writer
+	messageID  string
+	boundaries int
+}
+
+func (mw *messageWriter) writeHeaders(msg contracts.MessageInterface, opts Options) {
+	mw.writeHeader("Date", opts.Date.Format(time.RFC1123Z))
+	mw.writeHeader("Message-ID", "<"+opts.MessageID+">")
+	mw.writeHeader("MIME-Version", "1.0")
+
+	if !msg.GetFrom().IsEmpty() {
+		mw.writeHeader("From", formatAddress(msg.GetFrom()))
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		mw.writeHeader("Reply-To", formatAddress(msg.GetReplyTo()))
+	}
+
+	mw.writeAddressListHeader("To", msg.GetTo())
+	mw.writeAddressListHeader("Cc", msg.GetCc())
+
+	if opts.IncludeBcc {
+		mw.writeAddressListHeader("Bcc", msg.GetBcc())
+	}
+
+	mw.writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.GetSubject()))
+
+	keys := make([]string, 0, len(opts.Headers))
+	for k := range opts.Headers {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		mw.writeHeader(textproto.CanonicalMIMEHeaderKey(k), mime.QEncoding.Encode("utf-8", opts.Headers[k]))
+	}
+}
+
+func (mw *messageWriter) writeAddressListHeader(key string, list mailing.MailAddressListInterface) {
+	if list == nil || list.IsEmpty() {
+		return
+	}
+
+	addrs := make([]string, 0, len(list.GetList()))
+	for _, addr := range list.GetList() {
+		addrs = append(addrs, formatAddress(addr))
+	}
+
+	mw.writeHeader(key, strings.Join(addrs, ", "))
+}
+
+// writeHeader writes header line folded at spaces to keep lines shorter than maxLineLength where possible.
+func (mw *messageWriter) writeHeader(key string, value string) {
+	line := key + ":"
+
+	for i, word := range strings.Split(value, " ") {
+		if i != 0 && len(line)+1+len(word) > maxLineLength {
+			_, _ = mw.w.WriteString(line + crlf)
+			line = ""
+		}
+
+		line += " " + word
+	}
+
+	_, _ = mw.w.WriteString(line + crlf)
+}
+
+func (mw *messageWriter) writeBody(msg contracts.MessageInterface) error {
+	files := make([]contracts.MessageAttachmentInterface, 0)
+	inlines := make([]contracts.MessageAttachmentInterface, 0)
+
+	for _, att := range msg.GetAttachments().GetList() {
+		if att.GetAttachMethod() == contracts.AttachMethodInline {
+			inlines = append(inlines, att)
+		} else {
+			files = append(files, att)
+		}
+	}
+
+	if len(files) == 0 {
+		return mw.writeRelated(mw.createBody, msg, inlines)
+	}
+
+	return mw.writeMultipart(mw.createBody, "multipart/mixed", func(create createPart) error {
+		if err := mw.writeRelated(create, msg, inlines); err != nil {
+			return err
+		}
+
+		for _, att := range files {
+			if err := writeAttachment(create, att); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// createPart writes part header and returns writer of part body.
+type createPart func(header textproto.MIMEHeader) (io.Writer, error)
+
+// createBody writes top level part header after message headers and returns message body writer.
+func (mw *messageWriter) createBody(header textproto.MIMEHeader) (io.Writer, error) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		mw.writeHeader(k, header.Get(k))
+	}
+
+	_, _ = mw.w.WriteString(crlf)
+
+	return mw.w, nil
+}
+
+func (mw *messageWriter) writeRelated(create createPart, msg contracts.MessageInterface, inlines []contracts.MessageAttachmentInterface) error {
+	if len(inlines) == 0 {
+		return mw.writeText(create, msg)
+	}
+
+	return mw.writeMultipart(create, "multipart/related", func(create createPart) error {
+		if err := mw.writeText(create, msg); err != nil {
+			return err
+		}
+
+		for _, att := range inlines {
+			if err := writeAttachment(create, att); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (mw *messageWriter) writeText(create createPart, msg contracts.MessageInterface) error {
+	if !msg.IsAlternative() {
+		mimeType := msg.GetMimeType()
+		if mimeType.IsEmpty() {
+			mimeType = customMime.TextPlain
+		}
+
+		return writeTextPart(create, mimeType, msg.GetBody())
+	}
+
+	return mw.writeMultipart(create, "multipart/alternative", func(create createPart) error {
+		if err := writeTextPart(create, customMime.TextPlain, msg.GetPlainText()); err != nil {
+			return err
+		}
+
+		return writeTextPart(create, customMime.TextHTML, msg.GetHTML())
+	})
+}
+
+func (mw *messageWriter) writeMultipart(create createPart, mediaType string, fill func(create createPart) error) error {
+	boundary := mw.nextBoundary()
+
+	w, err := create(textproto.MIMEHeader{
+		"Content-Type": {mime.FormatMediaType(mediaType, map[string]string{"boundary": boundary})},
+	})
+	if err != nil {
+		return fmt.Errorf("%s part create error: %w", mediaType, err)
+	}
+
+	parts := multipart.NewWriter(w)
+	if err := parts.SetBoundary(boundary); err != nil {
+		return fmt.Errorf("%s boundary error: %w", mediaType, err)
+	}
+
+	if err := fill(parts.CreatePart); err != nil {
+		return err
+	}
+
+	if err := parts.Close(); err != nil {
+		return fmt.Errorf("%s close error: %w", mediaType, err)
+	}
+
+	return nil
+}
+
+func (mw *messageWriter) nextBoundary() string {
+	mw.boundaries++
+
+	return fmt.Sprintf("%x", sha1.Sum([]byte(mw.messageID+"/"+strconv.Itoa(mw.boundaries)))) // nolint: gosec
+}
+
+// writeTextPart writes text as is if it is 7bit ascii, quoted-printable encoded otherwise.
+func writeTextPart(create createPart, mimeType customMime.Type, body []byte) error {
+	encoding := textTransferEncoding(body)
+
+	w, err := create(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(mimeType.String(), map[string]string{"charset": "utf-8"})},
+		"Content-Transfer-Encoding": {encoding},
+	})
+	if err != nil {
+		return fmt.Errorf("%s part create error: %w", mimeType, err)
+	}
+
+	if encoding == "7bit" {
+		if _, err := w.Write(normalizeLineBreaks(body)); err != nil {
+			return fmt.Errorf("%s part write error: %w", mimeType, err)
+		}
+
+		return nil
+	}
+
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write(body); err != nil {
+		return fmt.Errorf("%s part write error: %w", mimeType, err)
+	}
+
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("%s part write error: %w", mimeType, err)
+	}
+
+	return nil
+}
+
+func writeAttachment(create createPart, att contracts.MessageAttachmentInterface) error {
+	w, err := create(attachmentHeader(att))
+	if err != nil {
+		return fmt.Errorf("attachment %s part create error: %w", contracts.AttachmentFileName(att), err)
+	}
+
+	return writeBase64(w, att.GetContent())
+}
+
+// textTransferEncoding returns 7bit for short lines ascii text and quoted-printable otherwise.
+func textTransferEncoding(body []byte) string {
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		if len(line) > max7bitLineLength {
+			return "quoted-printable"
+		}
+
+		for _, c := range line {
+			if c >= 0x80 || (c < 0x20 && c != '\t' && c != '\r') || c == 0x7f {
+				return "quoted-printable"
+			}
+		}
+	}
+
+	return "7bit"
+}
+
+func attachmentHeader(att contracts.MessageAttachmentInterface) textproto.MIMEHeader {
+	fileName := contracts.AttachmentFileName(att)
+
+	mimeType := att.GetMimeType()
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	disposition := "attachment"
+	header := textproto.MIMEHeader{}
+
+	if att.GetAttachMethod() == contracts.AttachMethodInline {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+contentID(fileName)+">")
+	}
+
+	header.Set("Content-Type", mime.FormatMediaType(mimeType, map[string]string{"name": fileName}))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": fileName}))
+	header.Set("Content-Transfer-Encoding", "base64")
+
+	return header
+}
+
+// contentID returns file name as content id with percent-encoded characters not allowed in it, such as CR, LF,
+// "<" and ">", and non ascii ones, which are percent-encoded in filename parameter too.
+func contentID(fileName string) string {
+	b := &strings.Builder{}
+
+	for i := 0; i < len(fileName); i++ {
+		c := fileName[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),:;<>[\]%`, c) != -1 {
+			fmt.Fprintf(b, "%%%02X", c)
+
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// writeBase64 writes base64 encoded content split into maxLineLength lines.
+func writeBase64(w io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+
+	for len(encoded) > 0 {
+		n := maxLineLength
+		if len(encoded) < n {
+			n = len(encoded)
+		}
+
+		if _, err := io.WriteString(w, encoded[:n]+crlf); err != nil {
+			return fmt.Errorf("base64 write error: %w", err)
+		}
+
+		encoded = encoded[n:]
+	}
+
+	return nil
+}
+
+func formatAddress(addr mailing.MailAddressInterface) string {
+	return (&mail.Address{Name: addr.GetName(), Address: addr.GetEmail()}).String()
+}
+
+// normalizeLineBreaks converts LF and CR line breaks into CRLF ones.
+func normalizeLineBreaks(body []byte) []byte {
+	body = bytes.ReplaceAll(body, []byte(crlf), []byte("\n"))
+	body = bytes.ReplaceAll(body, []byte("\r"), []byte("\n"))
+
+	return bytes.ReplaceAll(body, []byte("\n"), []byte(crlf))
+}
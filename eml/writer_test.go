@@ -0,0 +1,186 @@
+package eml_test
+
+import (
+	"bytes"
+	"flag"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	cfgmime "github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var testOptions = eml.Options{
+	MessageID: "1.abc@spacetab.io",
+	Date:      time.Date(2021, 11, 5, 10, 30, 0, 0, time.UTC),
+}
+
+func newTestMessages() map[string]contracts.Message {
+	base := contracts.Message{
+		From:    mailing.MailAddress{Email: "from@spacetab.io", Name: "From"},
+		ReplyTo: mailing.MailAddress{Email: "reply@spacetab.io", Name: "Reply"},
+		To:      mailing.MailAddressList{{Email: "to@spacetab.io", Name: "To"}},
+		Cc:      mailing.MailAddressList{{Email: "cc@spacetab.io", Name: "Cc"}},
+		Bcc:     mailing.MailAddressList{{Email: "bcc@spacetab.io", Name: "Bcc"}},
+		Subject: "Test email",
+	}
+
+	plain := base
+	plain.MimeType = cfgmime.TextPlain
+	plain.Content = []byte("test email content\nsecond line")
+
+	html := base
+	html.MimeType = cfgmime.TextHTML
+	html.Content = []byte("<p>test email content</p>")
+
+	alternative := base
+	alternative.HTML = []byte("<p>test email content <img src=\"cid:logo.png\"></p>")
+	alternative.PlainText = []byte("test email content")
+
+	attachments := alternative
+	attachments.Attachments = contracts.MessageAttachmentList{
+		{MimeType: "image/png", AttachMethod: contracts.AttachMethodInline, Filename: "logo.png", Content: []byte("png")},
+		{MimeType: "application/pdf", AttachMethod: contracts.AttachMethodFile, Filename: "report.pdf", Content: bytes.Repeat([]byte("pdf"), 30)},
+	}
+
+	unicode := base
+	unicode.From = mailing.MailAddress{Email: "from@spacetab.io", Name: "Отправитель"}
+	unicode.Subject = "Тестовое письмо with a long subject which has to be folded"
+	unicode.MimeType = cfgmime.TextPlain
+	unicode.Content = []byte("Привет, мир")
+	unicode.Attachments = contracts.MessageAttachmentList{
+		{MimeType: "text/plain", AttachMethod: contracts.AttachMethodFile, Filename: "отчёт.txt", Content: []byte("отчёт")},
+	}
+
+	return map[string]contracts.Message{
+		"plain":       plain,
+		"html":        html,
+		"alternative": alternative,
+		"attachments": attachments,
+		"unicode":     unicode,
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	for name, msg := range newTestMessages() {
+		name, msg := name, msg
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := eml.Marshal(&msg, testOptions)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			golden := filepath.Join("testdata", name+".eml")
+
+			if *update {
+				if !assert.NoError(t, os.WriteFile(golden, got, 0o600)) {
+					t.FailNow()
+				}
+			}
+
+			exp, err := os.ReadFile(golden)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, string(exp), string(got))
+		})
+	}
+}
+
+func TestMarshal_Headers(t *testing.T) {
+	t.Parallel()
+
+	msg := newTestMessages()["unicode"]
+
+	raw, err := eml.Marshal(&msg, eml.Options{Headers: map[string]string{"x-campaign": "Осень"}, IncludeBcc: true})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	dec := &mime.WordDecoder{}
+
+	subject, err := dec.DecodeHeader(parsed.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, msg.Subject, subject)
+
+	campaign, err := dec.DecodeHeader(parsed.Header.Get("X-Campaign"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Осень", campaign)
+
+	from, err := parsed.Header.AddressList("From")
+	assert.NoError(t, err)
+	assert.Equal(t, []*mail.Address{{Name: "Отправитель", Address: "from@spacetab.io"}}, from)
+
+	bcc, err := parsed.Header.AddressList("Bcc")
+	assert.NoError(t, err)
+	assert.Equal(t, []*mail.Address{{Name: "Bcc", Address: "bcc@spacetab.io"}}, bcc)
+
+	date, err := parsed.Header.Date()
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), date, time.Minute)
+
+	assert.Regexp(t, `^<[0-9a-z]+\.[0-9a-f]+@spacetab\.io>$`, parsed.Header.Get("Message-ID"))
+	assert.Equal(t, "1.0", parsed.Header.Get("MIME-Version"))
+}
+
+func TestMarshal_ContentID(t *testing.T) {
+	type testCase struct {
+		name     string
+		fileName string
+		exp      string
+	}
+
+	tcs := []testCase{
+		{name: "plain file name", fileName: "logo.png", exp: "<logo.png>"},
+		{name: "header injection", fileName: "logo.png>\r\nBcc: <bcc@spacetab.io", exp: "<logo.png%3E%0D%0ABcc%3A%20%3Cbcc@spacetab.io>"},
+		{name: "non ascii file name", fileName: "лого.png", exp: "<%D0%BB%D0%BE%D0%B3%D0%BE.png>"},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg := newTestMessages()["plain"]
+			msg.Attachments = contracts.MessageAttachmentList{
+				{MimeType: "image/png", AttachMethod: contracts.AttachMethodInline, Filename: tc.fileName, Content: []byte("png")},
+			}
+
+			raw, err := eml.Marshal(&msg, testOptions)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Contains(t, string(raw), "\r\nContent-Id: "+tc.exp+"\r\n")
+			assert.NotContains(t, string(raw), "\r\nBcc:")
+		})
+	}
+}
+
+func TestNewMessageID(t *testing.T) {
+	t.Parallel()
+
+	assert.NotEqual(t, eml.NewMessageID("spacetab.io"), eml.NewMessageID("spacetab.io"))
+	assert.Regexp(t, `@localhost$`, eml.NewMessageID(""))
+}
@@ -0,0 +1,10 @@
+package errors
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidDSN       = errors.New("invalid dsn")
+	ErrUnknownDSNScheme = errors.New("unknown dsn scheme")
+)
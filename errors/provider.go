@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNoProviders           = errors.New("no providers")
+	ErrAllProvidersFailed    = errors.New("all providers failed")
+	ErrAllRecipientsRejected = errors.New("all recipients are rejected")
+	ErrProviderClosed        = errors.New("provider is closed")
+)
+
+// ProvidersError holds errors of every tried provider. It matches ErrAllProvidersFailed
+// and unwraps to the last provider error.
+type ProvidersError struct {
+	Errors []error
+}
+
+func (e ProvidersError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return ErrAllProvidersFailed.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e ProvidersError) Is(target error) bool {
+	return target == ErrAllProvidersFailed // nolint: errorlint, goerr113
+}
+
+func (e ProvidersError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e.Errors[len(e.Errors)-1]
+}
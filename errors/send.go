@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+var (
+	ErrTemporary = errors.New("temporary send error")
+	ErrPermanent = errors.New("permanent send error")
+)
+
+// SendError is a provider send error classified as temporary (worth retrying) or permanent.
+// It matches ErrTemporary or ErrPermanent with errors.Is.
+type SendError struct {
+	// Code is smtp reply code, http status code or sendmail exit status, 0 if unknown.
+	Code      int
+	Temporary bool
+	Err       error
+}
+
+func (e SendError) Error() string {
+	kind := ErrPermanent
+	if e.Temporary {
+		kind = ErrTemporary
+	}
+
+	if e.Code != 0 {
+		return fmt.Sprintf("%s (%d): %s", kind, e.Code, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %s", kind, e.Err)
+}
+
+func (e SendError) Unwrap() error {
+	return e.Err
+}
+
+func (e SendError) Is(target error) bool {
+	return (target == ErrTemporary && e.Temporary) || (target == ErrPermanent && !e.Temporary) // nolint: errorlint, goerr113
+}
+
+// Temporary marks err as temporary one.
+func Temporary(code int, err error) error {
+	return SendError{Code: code, Temporary: true, Err: err}
+}
+
+// Permanent marks err as permanent one.
+func Permanent(code int, err error) error {
+	return SendError{Code: code, Temporary: false, Err: err}
+}
+
+// FromSMTPCode classifies err by smtp reply code: 4xx replies are temporary, others are permanent.
+func FromSMTPCode(code int, err error) error {
+	if code >= 400 && code < 500 {
+		return Temporary(code, err)
+	}
+
+	return Permanent(code, err)
+}
+
+// FromHTTPStatus classifies err by http status code: 408, 429 and 5xx are temporary, others are permanent.
+func FromHTTPStatus(status int, err error) error {
+	switch {
+	case status == http.StatusRequestTimeout, status == http.StatusTooManyRequests, status >= http.StatusInternalServerError:
+		return Temporary(status, err)
+	default:
+		return Permanent(status, err)
+	}
+}
+
+// FromTransport classifies http or smtp transport error: network errors are temporary,
+// context cancellation is left unclassified.
+func FromTransport(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var ne net.Error
+	if errors.As(err, &ne) {
+		return Temporary(0, err)
+	}
+
+	return err
+}
+
+// IsTemporary reports whether err is classified as temporary or is a network timeout.
+func IsTemporary(err error) bool {
+	var se SendError
+	if errors.As(err, &se) {
+		return se.Temporary
+	}
+
+	var ne net.Error
+
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// IsPermanent reports whether err is classified as permanent.
+func IsPermanent(err error) bool {
+	var se SendError
+
+	return errors.As(err, &se) && !se.Temporary
+}
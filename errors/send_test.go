@@ -0,0 +1,101 @@
+package errors_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("test")
+
+func TestIsTemporary(t *testing.T) {
+	type expStruct struct {
+		temporary bool
+		permanent bool
+	}
+	type testCase struct {
+		name string
+		in   error
+		exp  expStruct
+	}
+
+	tcs := []testCase{
+		{name: "smtp 4xx", in: mailErrors.FromSMTPCode(451, errTest), exp: expStruct{temporary: true}},
+		{name: "smtp 5xx", in: mailErrors.FromSMTPCode(550, errTest), exp: expStruct{permanent: true}},
+		{name: "http 429", in: mailErrors.FromHTTPStatus(429, errTest), exp: expStruct{temporary: true}},
+		{name: "http 503", in: mailErrors.FromHTTPStatus(503, errTest), exp: expStruct{temporary: true}},
+		{name: "http 400", in: mailErrors.FromHTTPStatus(400, errTest), exp: expStruct{permanent: true}},
+		{name: "network error", in: mailErrors.FromTransport(&net.OpError{Op: "dial", Err: errTest}), exp: expStruct{temporary: true}},
+		{name: "network timeout", in: &net.DNSError{IsTimeout: true}, exp: expStruct{temporary: true}},
+		{name: "context canceled", in: mailErrors.FromTransport(context.Canceled), exp: expStruct{}},
+		{name: "not classified", in: errTest, exp: expStruct{}},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.exp.temporary, mailErrors.IsTemporary(tc.in))
+			assert.Equal(t, tc.exp.permanent, mailErrors.IsPermanent(tc.in))
+		})
+	}
+}
+
+func TestSendError(t *testing.T) {
+	t.Parallel()
+
+	err := mailErrors.Temporary(421, errTest)
+
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, mailErrors.ErrTemporary)
+	assert.NotErrorIs(t, err, mailErrors.ErrPermanent)
+	assert.Equal(t, "temporary send error (421): test", err.Error())
+	assert.Equal(t, "permanent send error: test", mailErrors.Permanent(0, errTest).Error())
+}
+
+func TestFromExitCode(t *testing.T) {
+	type testCase struct {
+		name string
+		in   mailErrors.SendmailError
+		exp  error
+	}
+
+	tcs := []testCase{
+		{name: "temporary failure", in: mailErrors.SendmailError{ExitCode: 75}, exp: mailErrors.ErrTemporary},
+		{name: "io error", in: mailErrors.SendmailError{ExitCode: 74}, exp: mailErrors.ErrTemporary},
+		{name: "no user", in: mailErrors.SendmailError{ExitCode: 67}, exp: mailErrors.ErrPermanent},
+		{name: "killed", in: mailErrors.SendmailError{ExitCode: -1}, exp: mailErrors.ErrPermanent},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := mailErrors.FromExitCode(tc.in)
+
+			assert.ErrorIs(t, err, tc.exp)
+
+			var se mailErrors.SendmailError
+			if assert.ErrorAs(t, err, &se) {
+				assert.Equal(t, tc.in.ExitCode, se.ExitCode)
+			}
+		})
+	}
+}
+
+func TestSendmailError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "sendmail exited with status 67: user unknown", mailErrors.SendmailError{ExitCode: 67, Stderr: "user unknown\n"}.Error())
+	assert.Equal(t, "sendmail exited with status 75", mailErrors.SendmailError{ExitCode: 75}.Error())
+}
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sysexits.h codes, which sendmail compatible binaries exit with.
+const (
+	exitOSErr    = 71
+	exitIOErr    = 74
+	exitTempFail = 75
+)
+
+// SendmailError is a failed sendmail process with its exit status and standard error output.
+type SendmailError struct {
+	// ExitCode is process exit status, -1 if it was killed by a signal.
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+func (e SendmailError) Error() string {
+	if stderr := strings.TrimSpace(e.Stderr); stderr != "" {
+		return fmt.Sprintf("sendmail exited with status %d: %s", e.ExitCode, stderr)
+	}
+
+	return fmt.Sprintf("sendmail exited with status %d", e.ExitCode)
+}
+
+func (e SendmailError) Unwrap() error {
+	return e.Err
+}
+
+// FromExitCode classifies sendmail error by its exit status: EX_TEMPFAIL, EX_OSERR and EX_IOERR
+// are temporary, others are permanent.
+func FromExitCode(err SendmailError) error {
+	switch err.ExitCode {
+	case exitTempFail, exitOSErr, exitIOErr:
+		return Temporary(err.ExitCode, err)
+	default:
+		return Permanent(err.ExitCode, err)
+	}
+}
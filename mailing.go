@@ -5,89 +5,97 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
 
-	"github.com/spacetab-io/configuration-structs-go/v2/errors"
 	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
 	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/dsn"
 	"github.com/spacetab-io/mails-go/providers"
 )
 
 type Mailing struct {
-	provider contracts.ProviderInterface
-	msgCfg   mailing.MessagingConfigInterface
+	provider    contracts.ProviderInterface
+	msgCfg      mailing.MessagingConfigInterface
+	retry       RetryPolicy
+	middlewares []Middleware
 }
 
+// NewMailing returns Mailing sending messages with provider built by factory registered for config provider name,
+// see providers.Register.
 func NewMailing(providerCfg mailing.MailProviderConfigInterface, msgCfg mailing.MessagingConfigInterface) (Mailing, error) {
-	var (
-		provider contracts.ProviderInterface
-		err      error
-	)
-
-	switch providerCfg.Name() {
-	case mailing.MailProviderLogs:
-		var w io.Writer
-
-		switch providerCfg.GetHostPort().GetHost() {
-		case "stdout":
-			w = os.Stdout
-		case "stderr":
-			w = os.Stderr
-		default:
-			w = os.Stdout
-		}
-
-		logger := NewLogger(w)
-		provider, err = providers.NewLogProvider(mailing.LogsConfig{}, logger)
-	case mailing.MailProviderFile:
-		provider, err = providers.NewFileProvider(providerCfg)
-	case mailing.MailProviderMailgun:
-		provider, err = providers.NewMailgun(providerCfg)
-	case mailing.MailProviderMandrill:
-		provider, err = providers.NewMandrill(providerCfg)
-	case mailing.MailProviderSendgrid:
-		provider, err = providers.NewSendgrid(providerCfg)
-	case mailing.MailProviderSMTP:
-		provider, err = providers.NewSMTP(providerCfg)
-	default:
-		return Mailing{}, errors.ErrUnknownProvider
+	provider, err := providers.New(providerCfg)
+	if err != nil {
+		return Mailing{}, fmt.Errorf("provider init error: %w", err)
 	}
 
+	return NewMailingForProvider(provider, msgCfg), nil
+}
+
+// NewMailingFromDSN returns Mailing sending messages with provider configured by connection string, see package dsn.
+func NewMailingFromDSN(s string, msgCfg mailing.MessagingConfigInterface) (Mailing, error) {
+	d, err := dsn.Parse(s)
 	if err != nil {
-		return Mailing{}, fmt.Errorf("provider init error: %w", err)
+		return Mailing{}, fmt.Errorf("provider dsn parse error: %w", err)
 	}
 
-	return Mailing{provider: provider, msgCfg: msgCfg}, nil
+	return NewMailing(d.Config(), msgCfg)
 }
 
-func NewMailingForProvider(provider contracts.ProviderInterface, msgCfg mailing.MessagingConfigInterface) Mailing {
-	return Mailing{provider: provider, msgCfg: msgCfg}
+// nolint: gochecknoinits
+func init() {
+	providers.Register(mailing.MailProviderLogs, newLogProvider)
 }
 
-func (m Mailing) Send(ctx context.Context, msg contracts.MessageInterface) error {
-	if msg.GetMimeType().IsEmpty() && !m.msgCfg.GetMimeType().IsEmpty() {
-		msg.SetMimeType(m.msgCfg.GetMimeType())
-	}
+// newLogProvider builds log provider printing messages to stdout or stderr set as config host.
+func newLogProvider(providerCfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+	var w io.Writer
 
-	if msg.GetFrom().IsEmpty() && !m.msgCfg.GetFrom().IsEmpty() {
-		_ = msg.SetFrom(m.msgCfg.GetFrom())
+	switch providerCfg.GetHostPort().GetHost() {
+	case "stderr":
+		w = os.Stderr
+	default:
+		w = os.Stdout
 	}
 
-	if !m.msgCfg.GetReplyTo().IsEmpty() && msg.GetReplyTo().IsEmpty() {
-		_ = msg.SetReplyTo(m.msgCfg.GetReplyTo())
-	}
+	return providers.NewLogProvider(mailing.LogsConfig{}, NewLogger(w))
+}
 
-	if m.msgCfg.GetSubjectPrefix() != "" {
-		_ = msg.SetSubject(fmt.Sprintf(
-			"%s %s",
-			strings.TrimSpace(m.msgCfg.GetSubjectPrefix()),
-			strings.TrimSpace(msg.GetSubject()),
-		))
-	}
+func NewMailingForProvider(provider contracts.ProviderInterface, msgCfg mailing.MessagingConfigInterface) Mailing {
+	return Mailing{provider: provider, msgCfg: msgCfg, middlewares: configMiddlewares(msgCfg)}
+}
+
+// WithRetryPolicy returns Mailing retrying provider errors with policy p.
+func (m Mailing) WithRetryPolicy(p RetryPolicy) Mailing {
+	m.retry = p
+
+	return m
+}
+
+// Use returns Mailing with middlewares appended to the chain. Middlewares are called in order they are
+// registered, after built-in ones applying messaging config defaults, and wrap retried provider call.
+func (m Mailing) Use(middlewares ...Middleware) Mailing {
+	m.middlewares = append(append(make([]Middleware, 0, len(m.middlewares)+len(middlewares)), m.middlewares...), middlewares...)
+
+	return m
+}
 
-	if err := m.provider.Send(ctx, msg); err != nil {
-		return fmt.Errorf("mailing send error: %w", err)
+func (m Mailing) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := m.SendWithResult(ctx, msg)
+
+	return err
+}
+
+// SendWithResult sends message and returns provider message id and recipients statuses.
+// Providers not reporting them return result with provider name and send time only.
+// Middlewares get a copy of msg, so caller's message is never changed.
+func (m Mailing) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	send := chain(func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+		return m.retry.send(ctx, m.provider, msg)
+	}, m.middlewares...)
+
+	res, err := send(ctx, msg.Clone())
+	if err != nil {
+		return res, fmt.Errorf("mailing send error: %w", err)
 	}
 
-	return nil
+	return res, nil
 }
@@ -3,13 +3,19 @@ package mails_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	cfgErrors "github.com/spacetab-io/configuration-structs-go/v2/errors"
 	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
 	"github.com/spacetab-io/configuration-structs-go/v2/mime"
 	"github.com/spacetab-io/mails-go"
 	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
 	"github.com/spacetab-io/mails-go/providers"
 	"github.com/stretchr/testify/assert"
 )
@@ -110,3 +116,237 @@ func TestMailing_Send(t *testing.T) {
 		})
 	}
 }
+
+type failingProvider struct{}
+
+func (p failingProvider) Name() mailing.MailProviderName {
+	return "failing"
+}
+
+func (p failingProvider) Send(_ context.Context, _ contracts.MessageInterface) error {
+	return errors.New("connection refused") // nolint: goerr113
+}
+
+func TestMailing_SendFailover(t *testing.T) {
+	t.Parallel()
+
+	bb := &bytes.Buffer{}
+	logProvider, _ := providers.NewLogProvider(mailing.LogsConfig{}, mails.NewLogger(bb))
+
+	failover, err := providers.NewFailover(providers.FailoverConfig{Cooldown: time.Minute}, failingProvider{}, logProvider)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := contracts.Message{
+		From:     mailing.MailAddress{Email: "from@spacetab.io", Name: "FromName"},
+		To:       mailing.MailAddressList{mailing.MailAddress{Email: "toOne@spacetab.io", Name: "To One"}},
+		MimeType: mime.TextPlain,
+		Subject:  "Test email",
+		Content:  []byte("test email content"),
+	}
+
+	m := mails.NewMailingForProvider(failover, mailing.MessagingConfig{})
+
+	res, err := m.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "email by [logs]:\n"+msg.String(), bb.String())
+	assert.Equal(t, mailing.MailProviderLogs, res.Provider)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "toOne@spacetab.io", Status: "accepted"}}, res.Accepted)
+	assert.Equal(t, mailing.MailProviderLogs, failover.LastDeliveredBy())
+	assert.False(t, failover.Health()[0].Healthy)
+}
+
+func TestMailing_SendDoesNotChangeMessage(t *testing.T) {
+	t.Parallel()
+
+	msgCfg := mailing.MessagingConfig{
+		From:          mailing.MailAddress{Email: "robot@spacetab.io", Name: "Spacetab Robot"},
+		ReplyTo:       mailing.MailAddress{Email: "feedback@spacetab.io", Name: "Spacetab Feedback"},
+		SubjectPrefix: "[test]",
+	}
+
+	msg := contracts.Message{
+		To:      mailing.MailAddressList{{Email: "to@spacetab.io", Name: "To"}},
+		Subject: "Test email",
+		Content: []byte("test email content"),
+	}
+	orig := msg
+
+	prefixed := msg
+	prefixed.Subject = "[test] Test email"
+
+	exp := msg
+	exp.From = msgCfg.From
+	exp.ReplyTo = msgCfg.ReplyTo
+	exp.Subject = "[test] Test email"
+
+	provider := providers.NewMemory()
+	m := mails.NewMailingForProvider(provider, msgCfg)
+
+	for _, in := range []*contracts.Message{&msg, &msg, &prefixed} {
+		if !assert.NoError(t, m.Send(context.Background(), in)) {
+			t.FailNow()
+		}
+	}
+
+	assert.Equal(t, orig, msg)
+	assert.Equal(t, "[test] Test email", prefixed.Subject)
+	assert.Equal(t, []string{exp.String(), exp.String(), exp.String()}, sentStrings(provider))
+}
+
+func TestNewMailing(t *testing.T) {
+	type testCase struct {
+		name string
+		cfg  func(dir string) mailing.MailProviderConfigInterface
+		exp  func(t *testing.T, dir string)
+	}
+
+	tcs := []testCase{
+		{
+			name: "maildir",
+			cfg: func(dir string) mailing.MailProviderConfigInterface {
+				return providers.MaildirConfig{Dir: filepath.Join(dir, "Maildir")}
+			},
+			exp: func(t *testing.T, dir string) {
+				t.Helper()
+
+				entries, err := os.ReadDir(filepath.Join(dir, "Maildir", "new"))
+				assert.NoError(t, err)
+				assert.Len(t, entries, 1)
+			},
+		},
+		{
+			name: "mbox",
+			cfg: func(dir string) mailing.MailProviderConfigInterface {
+				return providers.MboxConfig{FilePath: filepath.Join(dir, "mbox")}
+			},
+			exp: func(t *testing.T, dir string) {
+				t.Helper()
+
+				content, err := os.ReadFile(filepath.Join(dir, "mbox"))
+				assert.NoError(t, err)
+				assert.Contains(t, string(content), "Subject: Test email\n")
+			},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			m, err := mails.NewMailing(tc.cfg(dir), mailing.MessagingConfig{From: mailing.MailAddress{Email: "robot@spacetab.io"}})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := contracts.Message{
+				To:       mailing.MailAddressList{{Email: "to@spacetab.io"}},
+				MimeType: mime.TextPlain,
+				Subject:  "Test email",
+				Content:  []byte("test email content"),
+			}
+
+			if !assert.NoError(t, m.Send(context.Background(), &msg)) {
+				t.FailNow()
+			}
+
+			tc.exp(t, dir)
+		})
+	}
+}
+
+// customProviderConfig is a config of provider registered outside of providers package.
+type customProviderConfig struct {
+	providers.MboxConfig
+}
+
+func (c customProviderConfig) Name() mailing.MailProviderName {
+	return "custom"
+}
+
+type unknownProviderConfig struct {
+	providers.MboxConfig
+}
+
+func (c unknownProviderConfig) Name() mailing.MailProviderName {
+	return "unknown"
+}
+
+func TestNewMailing_RegisteredProvider(t *testing.T) {
+	t.Parallel()
+
+	memory := providers.NewMemory()
+
+	providers.Register("custom", func(providerCfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return memory, nil
+	})
+
+	m, err := mails.NewMailing(customProviderConfig{}, mailing.MessagingConfig{From: mailing.MailAddress{Email: "robot@spacetab.io"}})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := contracts.Message{
+		To:       mailing.MailAddressList{{Email: "to@spacetab.io"}},
+		MimeType: mime.TextPlain,
+		Subject:  "Test email",
+		Content:  []byte("test email content"),
+	}
+
+	if !assert.NoError(t, m.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	assert.Len(t, memory.SentTo("to@spacetab.io"), 1)
+
+	_, err = mails.NewMailing(providers.MboxConfig{}, mailing.MessagingConfig{})
+	assert.Error(t, err)
+}
+
+func TestNewMailing_UnknownProvider(t *testing.T) {
+	t.Parallel()
+
+	_, err := mails.NewMailing(unknownProviderConfig{}, mailing.MessagingConfig{})
+	assert.ErrorIs(t, err, cfgErrors.ErrUnknownProvider)
+}
+
+func TestNewMailingFromDSN(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	m, err := mails.NewMailingFromDSN("maildir://"+filepath.Join(dir, "Maildir"), mailing.MessagingConfig{From: mailing.MailAddress{Email: "robot@spacetab.io"}})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := contracts.Message{
+		To:       mailing.MailAddressList{{Email: "to@spacetab.io"}},
+		MimeType: mime.TextPlain,
+		Subject:  "Test email",
+		Content:  []byte("test email content"),
+	}
+
+	if !assert.NoError(t, m.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "Maildir", "new"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	_, err = mails.NewMailingFromDSN("log://stdout", mailing.MessagingConfig{})
+	assert.NoError(t, err)
+
+	_, err = mails.NewMailingFromDSN("pigeon://home", mailing.MessagingConfig{})
+	assert.ErrorIs(t, err, mailErrors.ErrUnknownDSNScheme)
+}
@@ -0,0 +1,106 @@
+// Package mailstest provides assertions over messages recorded by providers.Memory.
+package mailstest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/providers"
+)
+
+// WaitForMessages waits for n messages recorded by m during timeout, failing the test if they are not sent.
+func WaitForMessages(t testing.TB, m *providers.Memory, n int, timeout time.Duration) []contracts.MessageInterface {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	messages, err := m.Wait(ctx, n)
+	if err != nil {
+		t.Fatalf("expected %d sent messages in %s, got %d", n, timeout, len(messages))
+	}
+
+	return messages
+}
+
+// AssertSentTo checks that at least one message is sent to email and returns messages sent to it.
+func AssertSentTo(t testing.TB, m *providers.Memory, email string) []contracts.MessageInterface {
+	t.Helper()
+
+	messages := m.SentTo(email)
+	if len(messages) == 0 {
+		t.Errorf("expected message sent to %s, got none of %d sent messages", email, len(m.Messages()))
+	}
+
+	return messages
+}
+
+// AssertNotSentTo checks that no message is sent to email.
+func AssertNotSentTo(t testing.TB, m *providers.Memory, email string) bool {
+	t.Helper()
+
+	if messages := m.SentTo(email); len(messages) != 0 {
+		t.Errorf("expected no messages sent to %s, got %d", email, len(messages))
+
+		return false
+	}
+
+	return true
+}
+
+// AssertSentCount checks number of messages recorded by m.
+func AssertSentCount(t testing.TB, m *providers.Memory, n int) bool {
+	t.Helper()
+
+	if got := len(m.Messages()); got != n {
+		t.Errorf("expected %d sent messages, got %d", n, got)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertSubjectContains checks that message subject contains substr.
+func AssertSubjectContains(t testing.TB, msg contracts.MessageInterface, substr string) bool {
+	t.Helper()
+
+	if !strings.Contains(msg.GetSubject(), substr) {
+		t.Errorf("expected subject %q to contain %q", msg.GetSubject(), substr)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertBodyContains checks that message html or plain text body contains substr.
+func AssertBodyContains(t testing.TB, msg contracts.MessageInterface, substr string) bool {
+	t.Helper()
+
+	if !strings.Contains(string(msg.GetHTML()), substr) && !strings.Contains(string(msg.GetPlainText()), substr) {
+		t.Errorf("expected message %q body to contain %q", msg.GetSubject(), substr)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertAttachment checks that message has attachment with file name and returns it.
+func AssertAttachment(t testing.TB, msg contracts.MessageInterface, fileName string) contracts.MessageAttachmentInterface {
+	t.Helper()
+
+	for _, att := range msg.GetAttachments().GetList() {
+		if att.GetFileName() == fileName {
+			return att
+		}
+	}
+
+	t.Errorf("expected message %q to have attachment %s, got %v", msg.GetSubject(), fileName, msg.GetAttachments().GetFileNames())
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package mailstest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/mailstest"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT records helper failures instead of failing the test.
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingT) Fatalf(format string, args ...interface{}) {
+	t.Errorf(format, args...)
+}
+
+func newSentProvider(t *testing.T) *providers.Memory {
+	t.Helper()
+
+	m := providers.NewMemory()
+	msg := contracts.Message{
+		To:       mailing.MailAddressList{{Email: "to@spacetab.io"}},
+		MimeType: mime.TextHTML,
+		Subject:  "[test] Welcome",
+		Content:  []byte("<p>Hello</p>"),
+		Attachments: contracts.MessageAttachmentList{
+			{MimeType: "application/pdf", AttachMethod: contracts.AttachMethodFile, Filename: "report.pdf", Content: []byte("pdf")},
+		},
+	}
+
+	if !assert.NoError(t, m.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	return m
+}
+
+func TestAssertions(t *testing.T) {
+	type testCase struct {
+		name   string
+		assert func(t testing.TB, m *providers.Memory)
+		fails  bool
+	}
+
+	tcs := []testCase{
+		{name: "sent to", assert: func(t testing.TB, m *providers.Memory) { mailstest.AssertSentTo(t, m, "TO@spacetab.io") }},
+		{name: "not sent to", assert: func(t testing.TB, m *providers.Memory) { mailstest.AssertSentTo(t, m, "other@spacetab.io") }, fails: true},
+		{name: "no messages to", assert: func(t testing.TB, m *providers.Memory) { mailstest.AssertNotSentTo(t, m, "other@spacetab.io") }},
+		{name: "messages to", assert: func(t testing.TB, m *providers.Memory) { mailstest.AssertNotSentTo(t, m, "to@spacetab.io") }, fails: true},
+		{name: "sent count", assert: func(t testing.TB, m *providers.Memory) { mailstest.AssertSentCount(t, m, 1) }},
+		{name: "wrong sent count", assert: func(t testing.TB, m *providers.Memory) { mailstest.AssertSentCount(t, m, 2) }, fails: true},
+		{name: "subject contains", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.AssertSubjectContains(t, m.Messages()[0], "Welcome")
+		}},
+		{name: "subject does not contain", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.AssertSubjectContains(t, m.Messages()[0], "Goodbye")
+		}, fails: true},
+		{name: "body contains", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.AssertBodyContains(t, m.Messages()[0], "Hello")
+		}},
+		{name: "body does not contain", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.AssertBodyContains(t, m.Messages()[0], "Goodbye")
+		}, fails: true},
+		{name: "attachment", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.AssertAttachment(t, m.Messages()[0], "report.pdf")
+		}},
+		{name: "no attachment", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.AssertAttachment(t, m.Messages()[0], "invoice.pdf")
+		}, fails: true},
+		{name: "wait for sent messages", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.WaitForMessages(t, m, 1, 10*time.Millisecond)
+		}},
+		{name: "wait for not sent messages", assert: func(t testing.TB, m *providers.Memory) {
+			mailstest.WaitForMessages(t, m, 2, 10*time.Millisecond)
+		}, fails: true},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := &recordingT{TB: t}
+			tc.assert(rt, newSentProvider(t))
+
+			if tc.fails {
+				assert.Len(t, rt.failures, 1)
+			} else {
+				assert.Empty(t, rt.failures)
+			}
+		})
+	}
+}
+
+func TestAssertSentTo_FailedRecipient(t *testing.T) {
+	t.Parallel()
+
+	m := providers.NewMemory()
+	msg := contracts.Message{To: mailing.MailAddressList{{Email: "to@spacetab.io"}}, Subject: "Welcome"}
+
+	m.FailRecipient("to@spacetab.io", errors.New("rejected")) // nolint: goerr113
+	assert.Error(t, m.Send(context.Background(), &msg))
+	mailstest.AssertNotSentTo(t, m, "to@spacetab.io")
+
+	// nil error clears recipient failure
+	m.FailRecipient("to@spacetab.io", nil)
+	assert.NoError(t, m.Send(context.Background(), &msg))
+	mailstest.AssertSentTo(t, m, "to@spacetab.io")
+}
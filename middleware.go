@@ -0,0 +1,92 @@
+package mails
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+)
+
+// SendFunc sends message and returns its send result.
+type SendFunc func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error)
+
+// Middleware wraps SendFunc with cross-cutting behaviour: logging, metrics, message rewriting, etc.
+// Middleware may change message before calling next, inspect result and error after it or not call next at all.
+type Middleware func(next SendFunc) SendFunc
+
+// chain wraps send with middlewares, so the first middleware is the outermost one.
+func chain(send SendFunc, middlewares ...Middleware) SendFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		send = middlewares[i](send)
+	}
+
+	return send
+}
+
+// configMiddlewares returns built-in middlewares applying messaging config defaults.
+func configMiddlewares(msgCfg mailing.MessagingConfigInterface) []Middleware {
+	return []Middleware{
+		DefaultMimeType(msgCfg.GetMimeType()),
+		DefaultFrom(msgCfg.GetFrom()),
+		DefaultReplyTo(msgCfg.GetReplyTo()),
+		SubjectPrefix(msgCfg.GetSubjectPrefix()),
+	}
+}
+
+// DefaultMimeType sets message mime type if it is empty.
+func DefaultMimeType(typ mime.Type) Middleware {
+	return func(next SendFunc) SendFunc {
+		return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+			if msg.GetMimeType().IsEmpty() && !typ.IsEmpty() {
+				msg.SetMimeType(typ)
+			}
+
+			return next(ctx, msg)
+		}
+	}
+}
+
+// DefaultFrom sets message sender if it is empty.
+func DefaultFrom(from mailing.MailAddressInterface) Middleware {
+	return func(next SendFunc) SendFunc {
+		return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+			if msg.GetFrom().IsEmpty() && from != nil && !from.IsEmpty() {
+				_ = msg.SetFrom(from)
+			}
+
+			return next(ctx, msg)
+		}
+	}
+}
+
+// DefaultReplyTo sets message reply-to address if it is empty.
+func DefaultReplyTo(replyTo mailing.MailAddressInterface) Middleware {
+	return func(next SendFunc) SendFunc {
+		return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+			if msg.GetReplyTo().IsEmpty() && replyTo != nil && !replyTo.IsEmpty() {
+				_ = msg.SetReplyTo(replyTo)
+			}
+
+			return next(ctx, msg)
+		}
+	}
+}
+
+// SubjectPrefix prepends prefix to message subject unless subject already starts with it as a separate word.
+func SubjectPrefix(prefix string) Middleware {
+	prefix = strings.TrimSpace(prefix)
+
+	return func(next SendFunc) SendFunc {
+		return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+			subject := strings.TrimSpace(msg.GetSubject())
+			if prefix != "" && subject != prefix && !strings.HasPrefix(subject, prefix+" ") {
+				_ = msg.SetSubject(fmt.Sprintf("%s %s", prefix, subject))
+			}
+
+			return next(ctx, msg)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package mails_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+// sentStrings returns string representations of messages recorded by memory provider.
+func sentStrings(m *providers.Memory) []string {
+	var sent []string
+
+	for _, msg := range m.Messages() {
+		sent = append(sent, msg.String())
+	}
+
+	return sent
+}
+
+func TestMailing_Use(t *testing.T) {
+	var errBlocked = errors.New("blocked") // nolint: goerr113
+
+	msgCfg := mailing.MessagingConfig{
+		From:          mailing.MailAddress{Email: "robot@spacetab.io", Name: "Spacetab Robot"},
+		SubjectPrefix: "[test]",
+	}
+
+	newMsg := func() contracts.Message {
+		return contracts.Message{
+			To:       mailing.MailAddressList{{Email: "to@spacetab.io", Name: "To"}},
+			MimeType: mime.TextPlain,
+			Subject:  "Test email",
+			Content:  []byte("test email content"),
+		}
+	}
+
+	// trace records middleware calls order.
+	trace := func(calls *[]string, name string) mails.Middleware {
+		return func(next mails.SendFunc) mails.SendFunc {
+			return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+				*calls = append(*calls, name+" before: "+msg.GetSubject())
+				res, err := next(ctx, msg)
+				*calls = append(*calls, name+" after")
+
+				return res, err
+			}
+		}
+	}
+
+	type expStruct struct {
+		calls []string
+		sent  func() []string
+		err   error
+	}
+
+	type testCase struct {
+		name        string
+		middlewares func(calls *[]string) []mails.Middleware
+		exp         expStruct
+	}
+
+	tcs := []testCase{
+		{
+			name: "middlewares are called in order after config defaults",
+			middlewares: func(calls *[]string) []mails.Middleware {
+				return []mails.Middleware{trace(calls, "first"), trace(calls, "second")}
+			},
+			exp: expStruct{
+				calls: []string{"first before: [test] Test email", "second before: [test] Test email", "second after", "first after"},
+				sent: func() []string {
+					msg := newMsg()
+					msg.From = msgCfg.From
+					msg.Subject = "[test] Test email"
+
+					return []string{msg.String()}
+				},
+			},
+		},
+		{
+			name: "audit copy recipient",
+			middlewares: func(_ *[]string) []mails.Middleware {
+				return []mails.Middleware{func(next mails.SendFunc) mails.SendFunc {
+					return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+						if err := msg.SetBcc(mailing.MailAddress{Email: "audit@spacetab.io", Name: "Audit"}); err != nil {
+							return contracts.SendResult{}, err
+						}
+
+						return next(ctx, msg)
+					}
+				}}
+			},
+			exp: expStruct{
+				calls: []string{},
+				sent: func() []string {
+					msg := newMsg()
+					msg.From = msgCfg.From
+					msg.Bcc = mailing.MailAddressList{{Email: "audit@spacetab.io", Name: "Audit"}}
+					msg.Subject = "[test] Test email"
+
+					return []string{msg.String()}
+				},
+			},
+		},
+		{
+			name: "short circuit",
+			middlewares: func(calls *[]string) []mails.Middleware {
+				return []mails.Middleware{trace(calls, "first"), func(next mails.SendFunc) mails.SendFunc {
+					return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+						return contracts.SendResult{}, errBlocked
+					}
+				}, trace(calls, "never")}
+			},
+			exp: expStruct{
+				calls: []string{"first before: [test] Test email", "first after"},
+				sent:  func() []string { return nil },
+				err:   errBlocked,
+			},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := make([]string, 0)
+			provider := providers.NewMemory()
+			m := mails.NewMailingForProvider(provider, msgCfg).Use(tc.middlewares(&calls)...)
+
+			msg := newMsg()
+			err := m.Send(context.Background(), &msg)
+
+			if tc.exp.err != nil {
+				assert.ErrorIs(t, err, tc.exp.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.exp.calls, calls)
+			assert.Equal(t, tc.exp.sent(), sentStrings(provider))
+		})
+	}
+}
+
+func TestMailing_UseDoesNotShareChain(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	counter := func(next mails.SendFunc) mails.SendFunc {
+		return func(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+			calls++
+
+			return next(ctx, msg)
+		}
+	}
+
+	base := mails.NewMailingForProvider(providers.NewMemory(), mailing.MessagingConfig{})
+	_ = base.Use(counter)
+
+	msg := contracts.Message{To: mailing.MailAddressList{{Email: "to@spacetab.io"}}, Subject: "Test email"}
+	if !assert.NoError(t, base.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 0, calls)
+}
+
+func TestSubjectPrefix(t *testing.T) {
+	type testCase struct {
+		name    string
+		prefix  string
+		subject string
+		exp     string
+	}
+
+	tcs := []testCase{
+		{name: "prefix is added", prefix: "[test]", subject: "Test email", exp: "[test] Test email"},
+		{name: "prefixed subject", prefix: "[test]", subject: "[test] Test email", exp: "[test] Test email"},
+		{name: "subject equal to prefix", prefix: "test", subject: " test ", exp: " test "},
+		{name: "subject starting with prefix word", prefix: "test", subject: "test results", exp: "test results"},
+		{name: "subject starting with prefix letters", prefix: "test", subject: "testing results", exp: "test testing results"},
+		{name: "empty prefix", prefix: " ", subject: "Test email", exp: "Test email"},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var subject string
+
+			send := mails.SubjectPrefix(tc.prefix)(func(_ context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+				subject = msg.GetSubject()
+
+				return contracts.SendResult{}, nil
+			})
+
+			msg := contracts.Message{Subject: tc.subject}
+
+			_, err := send(context.Background(), &msg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, tc.exp, subject)
+		})
+	}
+}
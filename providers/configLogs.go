@@ -0,0 +1,18 @@
+package providers
+
+import (
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+// LogsConfig is mailing.LogsConfig named as log provider, mailing.LogsConfig is named mock.
+type LogsConfig struct {
+	mailing.LogsConfig `yaml:",inline"`
+}
+
+func (c LogsConfig) String() string {
+	return c.Name().String()
+}
+
+func (c LogsConfig) Name() mailing.MailProviderName {
+	return mailing.MailProviderLogs
+}
@@ -0,0 +1,127 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	MailProviderMSGraph mailing.MailProviderName = "msgraph"
+
+	msGraphDefaultAuthority = "https://login.microsoftonline.com"
+	msGraphDefaultEndpoint  = "https://graph.microsoft.com/v1.0"
+	msGraphDefaultScope     = "https://graph.microsoft.com/.default"
+)
+
+// MSGraphConfigInterface is a provider config with Microsoft Graph specific settings.
+type MSGraphConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetTenantID() string
+	GetAuthority() string
+	GetScope() string
+	GetSender() string
+	GetSaveToSentItems() bool
+}
+
+// MSGraphConfig configures Microsoft Graph provider, which authenticates as application with client credentials.
+// Sender is id or principal name of mailbox messages are sent from, message From address is used if it is empty.
+// Authority (https://login.microsoftonline.com), Endpoint (https://graph.microsoft.com/v1.0) and
+// Scope (https://graph.microsoft.com/.default) are overridden for national clouds or tests.
+type MSGraphConfig struct {
+	TenantID        string        `yaml:"tenantID" valid:"required"`
+	ClientID        string        `yaml:"clientID" valid:"required"`
+	ClientSecret    string        `yaml:"clientSecret" valid:"required"`
+	Sender          string        `yaml:"sender" valid:"optional"`
+	Authority       string        `yaml:"authority" valid:"optional"`
+	Endpoint        string        `yaml:"endpoint" valid:"optional"`
+	Scope           string        `yaml:"scope" valid:"optional"`
+	SaveToSentItems bool          `yaml:"saveToSentItems" valid:"-"`
+	SendTimeout     time.Duration `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c MSGraphConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c MSGraphConfig) String() string {
+	return c.Name().String()
+}
+
+func (c MSGraphConfig) Name() mailing.MailProviderName {
+	return MailProviderMSGraph
+}
+
+func (c MSGraphConfig) IsAsync() bool {
+	return true
+}
+
+func (c MSGraphConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeAPI
+}
+
+func (c MSGraphConfig) GetUsername() string {
+	return c.ClientID
+}
+
+func (c MSGraphConfig) GetPassword() string {
+	return c.ClientSecret
+}
+
+func (c MSGraphConfig) GetHostPort() cfgstructs.AddressInterface {
+	if c.Endpoint != "" {
+		return &cfgstructs.HostCfg{Host: c.Endpoint}
+	}
+
+	return &cfgstructs.HostCfg{Host: msGraphDefaultEndpoint}
+}
+
+func (c MSGraphConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c MSGraphConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c MSGraphConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c MSGraphConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c MSGraphConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c MSGraphConfig) GetTenantID() string {
+	return c.TenantID
+}
+
+func (c MSGraphConfig) GetAuthority() string {
+	if c.Authority == "" {
+		return msGraphDefaultAuthority
+	}
+
+	return c.Authority
+}
+
+func (c MSGraphConfig) GetScope() string {
+	if c.Scope == "" {
+		return msGraphDefaultScope
+	}
+
+	return c.Scope
+}
+
+func (c MSGraphConfig) GetSender() string {
+	return c.Sender
+}
+
+func (c MSGraphConfig) GetSaveToSentItems() bool {
+	return c.SaveToSentItems
+}
@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const MailProviderMaildir mailing.MailProviderName = "maildir"
+
+// MaildirConfig configures Maildir provider. Dir is a maildir root with tmp, new and cur subdirectories.
+type MaildirConfig struct {
+	Dir   string `yaml:"dir" valid:"required"`
+	Async bool   `yaml:"isAsync" valid:"-"`
+}
+
+func (c MaildirConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c MaildirConfig) String() string {
+	return c.Name().String()
+}
+
+func (c MaildirConfig) Name() mailing.MailProviderName {
+	return MailProviderMaildir
+}
+
+func (c MaildirConfig) IsAsync() bool {
+	return c.Async
+}
+
+func (c MaildirConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeNone
+}
+
+func (c MaildirConfig) GetUsername() string {
+	return ""
+}
+
+func (c MaildirConfig) GetPassword() string {
+	return ""
+}
+
+func (c MaildirConfig) GetHostPort() cfgstructs.AddressInterface {
+	return &cfgstructs.HostCfg{Host: c.Dir}
+}
+
+func (c MaildirConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c MaildirConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c MaildirConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c MaildirConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c MaildirConfig) GetSendTimeout() time.Duration {
+	return 0
+}
@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	MailProviderMailjet mailing.MailProviderName = "mailjet"
+
+	mailjetAPIBase = "https://api.mailjet.com"
+)
+
+// MailjetConfigInterface is a provider config with Mailjet specific settings.
+type MailjetConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetCustomCampaign() string
+	GetTemplateLanguage() bool
+	GetSandboxMode() bool
+}
+
+// MailjetConfig configures Mailjet provider. Endpoint overrides api base url https://api.mailjet.com.
+// TemplateLanguage enables Mailjet templating language in message bodies and SandboxMode validates
+// messages without sending them.
+type MailjetConfig struct {
+	APIKey           string        `yaml:"apiKey" valid:"required"`
+	SecretKey        string        `yaml:"secretKey" valid:"required"`
+	Endpoint         string        `yaml:"endpoint" valid:"optional"`
+	CustomCampaign   string        `yaml:"customCampaign" valid:"optional"`
+	TemplateLanguage bool          `yaml:"templateLanguage" valid:"-"`
+	SandboxMode      bool          `yaml:"sandboxMode" valid:"-"`
+	SendTimeout      time.Duration `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c MailjetConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c MailjetConfig) String() string {
+	return c.Name().String()
+}
+
+func (c MailjetConfig) Name() mailing.MailProviderName {
+	return MailProviderMailjet
+}
+
+func (c MailjetConfig) IsAsync() bool {
+	return true
+}
+
+func (c MailjetConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeAPI
+}
+
+func (c MailjetConfig) GetUsername() string {
+	return c.APIKey
+}
+
+func (c MailjetConfig) GetPassword() string {
+	return c.SecretKey
+}
+
+func (c MailjetConfig) GetHostPort() cfgstructs.AddressInterface {
+	if c.Endpoint != "" {
+		return &cfgstructs.HostCfg{Host: c.Endpoint}
+	}
+
+	return &cfgstructs.HostCfg{Host: mailjetAPIBase}
+}
+
+func (c MailjetConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c MailjetConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeBasic
+}
+
+func (c MailjetConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c MailjetConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c MailjetConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c MailjetConfig) GetCustomCampaign() string {
+	return c.CustomCampaign
+}
+
+func (c MailjetConfig) GetTemplateLanguage() bool {
+	return c.TemplateLanguage
+}
+
+func (c MailjetConfig) GetSandboxMode() bool {
+	return c.SandboxMode
+}
@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const MailProviderMbox mailing.MailProviderName = "mbox"
+
+// MboxConfig configures Mbox provider. FilePath is a path of mbox file, which is created if it does not exist.
+type MboxConfig struct {
+	FilePath string `yaml:"filePath" valid:"required"`
+	Async    bool   `yaml:"isAsync" valid:"-"`
+}
+
+func (c MboxConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c MboxConfig) String() string {
+	return c.Name().String()
+}
+
+func (c MboxConfig) Name() mailing.MailProviderName {
+	return MailProviderMbox
+}
+
+func (c MboxConfig) IsAsync() bool {
+	return c.Async
+}
+
+func (c MboxConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeNone
+}
+
+func (c MboxConfig) GetUsername() string {
+	return ""
+}
+
+func (c MboxConfig) GetPassword() string {
+	return ""
+}
+
+func (c MboxConfig) GetHostPort() cfgstructs.AddressInterface {
+	return &cfgstructs.HostCfg{Host: c.FilePath}
+}
+
+func (c MboxConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c MboxConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c MboxConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c MboxConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c MboxConfig) GetSendTimeout() time.Duration {
+	return 0
+}
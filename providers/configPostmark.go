@@ -0,0 +1,123 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	MailProviderPostmark mailing.MailProviderName = "postmark"
+
+	// PostmarkStreamTransactional and PostmarkStreamBroadcast are default Postmark message streams.
+	PostmarkStreamTransactional = "outbound"
+	PostmarkStreamBroadcast     = "broadcast"
+
+	postmarkAPIBase = "https://api.postmarkapp.com"
+)
+
+// PostmarkConfigInterface is a provider config with Postmark specific settings.
+type PostmarkConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetMessageStream() string
+	GetTag() string
+	GetMetadata() map[string]string
+	GetTrackOpens() *bool
+	GetTrackLinks() string
+}
+
+// PostmarkConfig configures Postmark provider. TrackLinks is one of None, HtmlAndText, HtmlOnly, TextOnly.
+// Server tracking settings are used when TrackOpens is nil or TrackLinks is empty.
+// Metadata is added to every sent message.
+type PostmarkConfig struct {
+	ServerToken   string            `yaml:"serverToken" valid:"required"`
+	Endpoint      string            `yaml:"endpoint" valid:"optional"`
+	MessageStream string            `yaml:"messageStream" valid:"optional"`
+	Tag           string            `yaml:"tag" valid:"optional"`
+	Metadata      map[string]string `yaml:"metadata" valid:"-"`
+	TrackOpens    *bool             `yaml:"trackOpens" valid:"-"`
+	TrackLinks    string            `yaml:"trackLinks" valid:"optional,in(None|HtmlAndText|HtmlOnly|TextOnly)"`
+	SendTimeout   time.Duration     `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c PostmarkConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c PostmarkConfig) String() string {
+	return c.Name().String()
+}
+
+func (c PostmarkConfig) Name() mailing.MailProviderName {
+	return MailProviderPostmark
+}
+
+func (c PostmarkConfig) IsAsync() bool {
+	return true
+}
+
+func (c PostmarkConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeAPI
+}
+
+func (c PostmarkConfig) GetUsername() string {
+	return ""
+}
+
+func (c PostmarkConfig) GetPassword() string {
+	return c.ServerToken
+}
+
+func (c PostmarkConfig) GetHostPort() cfgstructs.AddressInterface {
+	if c.Endpoint != "" {
+		return &cfgstructs.HostCfg{Host: c.Endpoint}
+	}
+
+	return &cfgstructs.HostCfg{Host: postmarkAPIBase}
+}
+
+func (c PostmarkConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c PostmarkConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c PostmarkConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c PostmarkConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c PostmarkConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c PostmarkConfig) GetMessageStream() string {
+	if c.MessageStream == "" {
+		return PostmarkStreamTransactional
+	}
+
+	return c.MessageStream
+}
+
+func (c PostmarkConfig) GetTag() string {
+	return c.Tag
+}
+
+func (c PostmarkConfig) GetMetadata() map[string]string {
+	return c.Metadata
+}
+
+func (c PostmarkConfig) GetTrackOpens() *bool {
+	return c.TrackOpens
+}
+
+func (c PostmarkConfig) GetTrackLinks() string {
+	return c.TrackLinks
+}
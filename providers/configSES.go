@@ -0,0 +1,111 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const MailProviderSES mailing.MailProviderName = "ses"
+
+// SESConfigInterface is a provider config with SES specific settings.
+type SESConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetRegion() string
+	GetSessionToken() string
+	GetConfigurationSet() string
+	GetTags() map[string]string
+	GetHeaders() map[string]string
+}
+
+// SESConfig configures SES provider. Endpoint overrides regional api endpoint https://email.<region>.amazonaws.com,
+// Tags are added to every sent message and Headers are extra headers of raw message.
+type SESConfig struct {
+	Region           string            `yaml:"region" valid:"required"`
+	AccessKeyID      string            `yaml:"accessKeyID" valid:"required"`
+	SecretAccessKey  string            `yaml:"secretAccessKey" valid:"required"`
+	SessionToken     string            `yaml:"sessionToken" valid:"optional"`
+	Endpoint         string            `yaml:"endpoint" valid:"optional"`
+	ConfigurationSet string            `yaml:"configurationSet" valid:"optional"`
+	Tags             map[string]string `yaml:"tags" valid:"-"`
+	Headers          map[string]string `yaml:"headers" valid:"-"`
+	SendTimeout      time.Duration     `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c SESConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c SESConfig) String() string {
+	return c.Name().String()
+}
+
+func (c SESConfig) Name() mailing.MailProviderName {
+	return MailProviderSES
+}
+
+func (c SESConfig) IsAsync() bool {
+	return true
+}
+
+func (c SESConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeAPI
+}
+
+func (c SESConfig) GetUsername() string {
+	return c.AccessKeyID
+}
+
+func (c SESConfig) GetPassword() string {
+	return c.SecretAccessKey
+}
+
+func (c SESConfig) GetHostPort() cfgstructs.AddressInterface {
+	if c.Endpoint != "" {
+		return &cfgstructs.HostCfg{Host: c.Endpoint}
+	}
+
+	return &cfgstructs.HostCfg{Host: "https://email." + c.Region + ".amazonaws.com"}
+}
+
+func (c SESConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c SESConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c SESConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c SESConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c SESConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c SESConfig) GetRegion() string {
+	return c.Region
+}
+
+func (c SESConfig) GetSessionToken() string {
+	return c.SessionToken
+}
+
+func (c SESConfig) GetConfigurationSet() string {
+	return c.ConfigurationSet
+}
+
+func (c SESConfig) GetTags() map[string]string {
+	return c.Tags
+}
+
+func (c SESConfig) GetHeaders() map[string]string {
+	return c.Headers
+}
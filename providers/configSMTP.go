@@ -0,0 +1,59 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	smtpDefaultMaxConnections = 4
+	smtpDefaultIdleTimeout    = 30 * time.Second
+)
+
+// SMTPPoolConfigInterface is a provider config with smtp connection pool settings.
+type SMTPPoolConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetMaxConnections() int
+	GetIdleTimeout() time.Duration
+}
+
+// SMTPConfig is mailing.SMTPConfig with connection pool settings. MaxConnections is 4 and IdleTimeout
+// is 30 seconds by default; mailing.SMTPConfig passed to NewSMTP gets the same defaults.
+type SMTPConfig struct {
+	mailing.SMTPConfig `yaml:",inline"`
+
+	MaxConnections int           `yaml:"maxConnections" valid:"-"`
+	IdleTimeout    time.Duration `yaml:"idleTimeout" valid:"-"`
+}
+
+func (c SMTPConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c SMTPConfig) String() string {
+	return c.Name().String()
+}
+
+// Name returns smtp provider name, mailing.SMTPConfig is named as sendgrid one.
+func (c SMTPConfig) Name() mailing.MailProviderName {
+	return mailing.MailProviderSMTP
+}
+
+func (c SMTPConfig) GetMaxConnections() int {
+	if c.MaxConnections <= 0 {
+		return smtpDefaultMaxConnections
+	}
+
+	return c.MaxConnections
+}
+
+func (c SMTPConfig) GetIdleTimeout() time.Duration {
+	if c.IdleTimeout <= 0 {
+		return smtpDefaultIdleTimeout
+	}
+
+	return c.IdleTimeout
+}
@@ -0,0 +1,101 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	MailProviderSendmail mailing.MailProviderName = "sendmail"
+
+	sendmailDefaultPath = "/usr/sbin/sendmail"
+)
+
+// sendmailDefaultArgs make sendmail read recipients from message headers and not to stop on a line with single dot.
+var sendmailDefaultArgs = []string{"-t", "-i"}
+
+// SendmailConfigInterface is a provider config with sendmail binary settings.
+type SendmailConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetPath() string
+	GetArgs() []string
+}
+
+// SendmailConfig configures Sendmail provider. Path is /usr/sbin/sendmail and Args are "-t -i" by default.
+type SendmailConfig struct {
+	Path        string        `yaml:"path" valid:"optional"`
+	Args        []string      `yaml:"args" valid:"-"`
+	Async       bool          `yaml:"isAsync" valid:"-"`
+	SendTimeout time.Duration `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c SendmailConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c SendmailConfig) String() string {
+	return c.Name().String()
+}
+
+func (c SendmailConfig) Name() mailing.MailProviderName {
+	return MailProviderSendmail
+}
+
+func (c SendmailConfig) IsAsync() bool {
+	return c.Async
+}
+
+func (c SendmailConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeNone
+}
+
+func (c SendmailConfig) GetUsername() string {
+	return ""
+}
+
+func (c SendmailConfig) GetPassword() string {
+	return ""
+}
+
+func (c SendmailConfig) GetHostPort() cfgstructs.AddressInterface {
+	return &cfgstructs.HostCfg{Host: c.GetPath()}
+}
+
+func (c SendmailConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c SendmailConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c SendmailConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c SendmailConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c SendmailConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c SendmailConfig) GetPath() string {
+	if c.Path == "" {
+		return sendmailDefaultPath
+	}
+
+	return c.Path
+}
+
+func (c SendmailConfig) GetArgs() []string {
+	if c.Args == nil {
+		return sendmailDefaultArgs
+	}
+
+	return c.Args
+}
@@ -0,0 +1,124 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	MailProviderSparkPost mailing.MailProviderName = "sparkpost"
+
+	// SparkPostRegionUS and SparkPostRegionEU are SparkPost account regions.
+	SparkPostRegionUS = "us"
+	SparkPostRegionEU = "eu"
+
+	sparkPostAPIBase   = "https://api.sparkpost.com/api/v1"
+	sparkPostAPIBaseEU = "https://api.eu.sparkpost.com/api/v1"
+)
+
+// SparkPostConfigInterface is a provider config with SparkPost specific settings.
+type SparkPostConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetCampaignID() string
+	GetMetadata() map[string]string
+	GetTrackOpens() bool
+	GetTrackClicks() bool
+	GetTransactional() bool
+}
+
+// SparkPostConfig configures SparkPost provider. Region selects api of US (default) or EU account,
+// Endpoint overrides api base url. Metadata is added to every sent message, tracking is set by account
+// settings unless it is enabled here.
+type SparkPostConfig struct {
+	APIKey        string            `yaml:"apiKey" valid:"required"`
+	Region        string            `yaml:"region" valid:"optional,in(us|eu)"`
+	Endpoint      string            `yaml:"endpoint" valid:"optional"`
+	CampaignID    string            `yaml:"campaignID" valid:"optional"`
+	Metadata      map[string]string `yaml:"metadata" valid:"-"`
+	TrackOpens    bool              `yaml:"trackOpens" valid:"-"`
+	TrackClicks   bool              `yaml:"trackClicks" valid:"-"`
+	Transactional bool              `yaml:"transactional" valid:"-"`
+	SendTimeout   time.Duration     `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c SparkPostConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c SparkPostConfig) String() string {
+	return c.Name().String()
+}
+
+func (c SparkPostConfig) Name() mailing.MailProviderName {
+	return MailProviderSparkPost
+}
+
+func (c SparkPostConfig) IsAsync() bool {
+	return true
+}
+
+func (c SparkPostConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeAPI
+}
+
+func (c SparkPostConfig) GetUsername() string {
+	return ""
+}
+
+func (c SparkPostConfig) GetPassword() string {
+	return c.APIKey
+}
+
+func (c SparkPostConfig) GetHostPort() cfgstructs.AddressInterface {
+	switch {
+	case c.Endpoint != "":
+		return &cfgstructs.HostCfg{Host: c.Endpoint}
+	case c.Region == SparkPostRegionEU:
+		return &cfgstructs.HostCfg{Host: sparkPostAPIBaseEU}
+	default:
+		return &cfgstructs.HostCfg{Host: sparkPostAPIBase}
+	}
+}
+
+func (c SparkPostConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c SparkPostConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c SparkPostConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c SparkPostConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c SparkPostConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c SparkPostConfig) GetCampaignID() string {
+	return c.CampaignID
+}
+
+func (c SparkPostConfig) GetMetadata() map[string]string {
+	return c.Metadata
+}
+
+func (c SparkPostConfig) GetTrackOpens() bool {
+	return c.TrackOpens
+}
+
+func (c SparkPostConfig) GetTrackClicks() bool {
+	return c.TrackClicks
+}
+
+func (c SparkPostConfig) GetTransactional() bool {
+	return c.Transactional
+}
@@ -0,0 +1,120 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	MailProviderUnisenderGo mailing.MailProviderName = "unisendergo"
+
+	unisenderGoAPIBase = "https://go1.unisender.ru/ru/transactional/api/v1"
+)
+
+// UnisenderGoConfigInterface is a provider config with Unisender Go specific settings.
+type UnisenderGoConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetTrackLinks() bool
+	GetTrackRead() bool
+	GetSkipUnsubscribe() bool
+	GetTemplateEngine() string
+	GetGlobalLanguage() string
+	GetTags() []string
+}
+
+// UnisenderGoConfig configures Unisender Go provider. Endpoint overrides api base url
+// https://go1.unisender.ru/ru/transactional/api/v1, TemplateEngine is one of simple, velocity or none.
+type UnisenderGoConfig struct {
+	APIKey          string        `yaml:"apiKey" valid:"required"`
+	Endpoint        string        `yaml:"endpoint" valid:"optional"`
+	TrackLinks      bool          `yaml:"trackLinks" valid:"-"`
+	TrackRead       bool          `yaml:"trackRead" valid:"-"`
+	SkipUnsubscribe bool          `yaml:"skipUnsubscribe" valid:"-"`
+	TemplateEngine  string        `yaml:"templateEngine" valid:"optional,in(simple|velocity|none)"`
+	GlobalLanguage  string        `yaml:"globalLanguage" valid:"optional"`
+	Tags            []string      `yaml:"tags" valid:"-"`
+	SendTimeout     time.Duration `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c UnisenderGoConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c UnisenderGoConfig) String() string {
+	return c.Name().String()
+}
+
+func (c UnisenderGoConfig) Name() mailing.MailProviderName {
+	return MailProviderUnisenderGo
+}
+
+func (c UnisenderGoConfig) IsAsync() bool {
+	return true
+}
+
+func (c UnisenderGoConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeAPI
+}
+
+func (c UnisenderGoConfig) GetUsername() string {
+	return ""
+}
+
+func (c UnisenderGoConfig) GetPassword() string {
+	return c.APIKey
+}
+
+func (c UnisenderGoConfig) GetHostPort() cfgstructs.AddressInterface {
+	if c.Endpoint != "" {
+		return &cfgstructs.HostCfg{Host: c.Endpoint}
+	}
+
+	return &cfgstructs.HostCfg{Host: unisenderGoAPIBase}
+}
+
+func (c UnisenderGoConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c UnisenderGoConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c UnisenderGoConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c UnisenderGoConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c UnisenderGoConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c UnisenderGoConfig) GetTrackLinks() bool {
+	return c.TrackLinks
+}
+
+func (c UnisenderGoConfig) GetTrackRead() bool {
+	return c.TrackRead
+}
+
+func (c UnisenderGoConfig) GetSkipUnsubscribe() bool {
+	return c.SkipUnsubscribe
+}
+
+func (c UnisenderGoConfig) GetTemplateEngine() string {
+	return c.TemplateEngine
+}
+
+func (c UnisenderGoConfig) GetGlobalLanguage() string {
+	return c.GlobalLanguage
+}
+
+func (c UnisenderGoConfig) GetTags() []string {
+	return c.Tags
+}
@@ -0,0 +1,108 @@
+package providers
+
+import (
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+)
+
+const (
+	MailProviderWebhook mailing.MailProviderName = "webhook"
+
+	// WebhookFormatJSON and WebhookFormatMIME are webhook request body formats.
+	WebhookFormatJSON = "json"
+	WebhookFormatMIME = "mime"
+)
+
+// WebhookConfigInterface is a provider config with webhook specific settings.
+type WebhookConfigInterface interface {
+	mailing.MailProviderConfigInterface
+
+	GetURL() string
+	GetFormat() string
+	GetSecret() string
+	GetHeaders() map[string]string
+}
+
+// WebhookConfig configures webhook provider. Format is json (default) or mime, requests are signed
+// with Secret if it is set.
+type WebhookConfig struct {
+	URL         string            `yaml:"url" valid:"required,url"`
+	Format      string            `yaml:"format" valid:"optional,in(json|mime)"`
+	Secret      string            `yaml:"secret" valid:"optional"`
+	Headers     map[string]string `yaml:"headers" valid:"-"`
+	SendTimeout time.Duration     `yaml:"sendTimeout" valid:"-"`
+}
+
+func (c WebhookConfig) Validate() (bool, error) {
+	return cfgstructs.ConfigValidate(c)
+}
+
+func (c WebhookConfig) String() string {
+	return c.Name().String()
+}
+
+func (c WebhookConfig) Name() mailing.MailProviderName {
+	return MailProviderWebhook
+}
+
+func (c WebhookConfig) IsAsync() bool {
+	return true
+}
+
+func (c WebhookConfig) ConnectionType() mailing.MailProviderConnectionType {
+	return mailing.MailProviderConnectionTypeAPI
+}
+
+func (c WebhookConfig) GetUsername() string {
+	return ""
+}
+
+func (c WebhookConfig) GetPassword() string {
+	return c.Secret
+}
+
+func (c WebhookConfig) GetHostPort() cfgstructs.AddressInterface {
+	return &cfgstructs.HostCfg{Host: c.URL}
+}
+
+func (c WebhookConfig) GetEncryption() mailing.MailProviderEncryption {
+	return mailing.MailProviderEncryptionNone
+}
+
+func (c WebhookConfig) GetAuthType() cfgstructs.AuthType {
+	return cfgstructs.AuthTypeNone
+}
+
+func (c WebhookConfig) GetDKIMPrivateKey() *string {
+	return nil
+}
+
+func (c WebhookConfig) GetConnectionTimeout() time.Duration {
+	return 0
+}
+
+func (c WebhookConfig) GetSendTimeout() time.Duration {
+	return c.SendTimeout
+}
+
+func (c WebhookConfig) GetURL() string {
+	return c.URL
+}
+
+func (c WebhookConfig) GetFormat() string {
+	if c.Format == "" {
+		return WebhookFormatJSON
+	}
+
+	return c.Format
+}
+
+func (c WebhookConfig) GetSecret() string {
+	return c.Secret
+}
+
+func (c WebhookConfig) GetHeaders() map[string]string {
+	return c.Headers
+}
@@ -0,0 +1,11 @@
+package providers
+
+import (
+	"net/http"
+	"time"
+)
+
+// SignV4 exposes request signer to tests.
+func SignV4(req *http.Request, body []byte, accessKeyID string, secretAccessKey string, region string, service string, now time.Time) {
+	signV4(req, body, sigV4Credentials{accessKeyID: accessKeyID, secretAccessKey: secretAccessKey}, region, service, now)
+}
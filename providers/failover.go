@@ -0,0 +1,189 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const MailProviderFailover mailing.MailProviderName = "failover"
+
+// FailoverConfig configures Failover provider.
+type FailoverConfig struct {
+	// Cooldown is a period unhealthy provider is skipped for.
+	Cooldown time.Duration
+	// FailureThreshold is a number of consecutive transient failures marking provider unhealthy. Default is 1.
+	FailureThreshold int
+	// IsTransient classifies provider errors. Failover falls over to the next provider on transient errors only.
+	// By default, every error except permanent ones and context cancellation is transient.
+	IsTransient func(err error) bool
+}
+
+// ProviderHealth is a provider health state snapshot.
+type ProviderHealth struct {
+	Name                mailing.MailProviderName
+	Healthy             bool
+	ConsecutiveFailures int
+	Delivered           int
+	LastError           error
+	LastFailureAt       time.Time
+	SkipUntil           time.Time
+}
+
+// Failover sends message with the first provider able to deliver it.
+type Failover struct {
+	providers []contracts.ProviderInterface
+	cfg       FailoverConfig
+
+	mu            sync.Mutex
+	health        []ProviderHealth
+	lastDelivered mailing.MailProviderName
+}
+
+func NewFailover(cfg FailoverConfig, providers ...contracts.ProviderInterface) (*Failover, error) {
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("failover provider init error: %w", mailErrors.ErrNoProviders)
+	}
+
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 1
+	}
+
+	if cfg.IsTransient == nil {
+		cfg.IsTransient = isTransient
+	}
+
+	health := make([]ProviderHealth, 0, len(providers))
+	for _, p := range providers {
+		health = append(health, ProviderHealth{Name: p.Name(), Healthy: true})
+	}
+
+	return &Failover{providers: providers, cfg: cfg, health: health}, nil
+}
+
+func (f *Failover) Name() mailing.MailProviderName {
+	return MailProviderFailover
+}
+
+func (f *Failover) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := f.SendWithResult(ctx, msg)
+
+	return err
+}
+
+// SendWithResult tries healthy providers in order, then the ones in cooldown as a last resort.
+// Permanent (not transient) error stops failing over. Result names the provider which delivered message.
+func (f *Failover) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	errs := make([]error, 0, len(f.providers))
+
+	for _, i := range f.sendOrder() {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+
+			break
+		}
+
+		p := f.providers[i]
+
+		res, err := contracts.SendWithResult(ctx, p, msg)
+		if err == nil {
+			f.markDelivered(i)
+
+			return res, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+
+		if !f.cfg.IsTransient(err) {
+			return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("failover send error: %w", err)
+		}
+
+		f.markFailed(i, err)
+	}
+
+	return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("failover send error: %w", mailErrors.ProvidersError{Errors: errs})
+}
+
+// LastDeliveredBy returns name of the provider which delivered the last message.
+func (f *Failover) LastDeliveredBy() mailing.MailProviderName {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.lastDelivered
+}
+
+// Health returns providers health state in configured order.
+func (f *Failover) Health() []ProviderHealth {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	health := make([]ProviderHealth, 0, len(f.health))
+
+	for _, h := range f.health {
+		h.Healthy = !now.Before(h.SkipUntil)
+		health = append(health, h)
+	}
+
+	return health
+}
+
+func (f *Failover) sendOrder() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	healthy := make([]int, 0, len(f.health))
+	cooling := make([]int, 0)
+
+	for i, h := range f.health {
+		if now.Before(h.SkipUntil) {
+			cooling = append(cooling, i)
+
+			continue
+		}
+
+		healthy = append(healthy, i)
+	}
+
+	return append(healthy, cooling...)
+}
+
+func (f *Failover) markDelivered(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.health[i].ConsecutiveFailures = 0
+	f.health[i].SkipUntil = time.Time{}
+	f.health[i].Delivered++
+	f.lastDelivered = f.health[i].Name
+}
+
+func (f *Failover) markFailed(i int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+
+	f.health[i].ConsecutiveFailures++
+	f.health[i].LastError = err
+	f.health[i].LastFailureAt = now
+
+	if f.health[i].ConsecutiveFailures >= f.cfg.FailureThreshold {
+		f.health[i].SkipUntil = now.Add(f.cfg.Cooldown)
+	}
+}
+
+func isTransient(err error) bool {
+	if mailErrors.IsPermanent(err) {
+		return false
+	}
+
+	return mailErrors.IsTemporary(err) || (!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,217 @@
+package providers_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTestTransient = errors.New("transient")
+	errTestPermanent = errors.New("permanent")
+)
+
+// stubProvider returns configured errors one by one and counts send calls.
+type stubProvider struct {
+	name  mailing.MailProviderName
+	errs  []error
+	mu    sync.Mutex
+	calls int
+}
+
+func (p *stubProvider) Name() mailing.MailProviderName {
+	return p.name
+}
+
+func (p *stubProvider) Send(_ context.Context, _ contracts.MessageInterface) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+
+	if len(p.errs) == 0 {
+		return nil
+	}
+
+	err := p.errs[0]
+	if len(p.errs) > 1 {
+		p.errs = p.errs[1:]
+	}
+
+	return err
+}
+
+func (p *stubProvider) sendCalls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.calls
+}
+
+func isTestTransient(err error) bool {
+	return errors.Is(err, errTestTransient)
+}
+
+func TestFailover_Send(t *testing.T) {
+	type expStruct struct {
+		deliveredBy mailing.MailProviderName
+		calls       []int
+		err         error
+	}
+	type testCase struct {
+		name string
+		in   []error
+		exp  expStruct
+	}
+
+	tcs := []testCase{
+		{
+			name: "primary delivers",
+			in:   []error{nil, nil},
+			exp:  expStruct{deliveredBy: "primary", calls: []int{1, 0}},
+		},
+		{
+			name: "transient error falls over",
+			in:   []error{errTestTransient, nil},
+			exp:  expStruct{deliveredBy: "backup", calls: []int{1, 1}},
+		},
+		{
+			name: "permanent error stops",
+			in:   []error{errTestPermanent, nil},
+			exp:  expStruct{calls: []int{1, 0}, err: errTestPermanent},
+		},
+		{
+			name: "all providers failed",
+			in:   []error{errTestTransient, errTestTransient},
+			exp:  expStruct{calls: []int{1, 1}, err: mailErrors.ErrAllProvidersFailed},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			primary := &stubProvider{name: "primary", errs: []error{tc.in[0]}}
+			backup := &stubProvider{name: "backup", errs: []error{tc.in[1]}}
+
+			failover, err := providers.NewFailover(providers.FailoverConfig{Cooldown: time.Hour, IsTransient: isTestTransient}, primary, backup)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			err = failover.Send(context.Background(), &msg)
+			if tc.exp.err != nil {
+				if !assert.ErrorIs(t, err, tc.exp.err) {
+					t.FailNow()
+				}
+			} else {
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+			}
+
+			assert.Equal(t, tc.exp.deliveredBy, failover.LastDeliveredBy())
+			assert.Equal(t, tc.exp.calls, []int{primary.sendCalls(), backup.sendCalls()})
+		})
+	}
+}
+
+func TestFailover_Cooldown(t *testing.T) {
+	t.Parallel()
+
+	msg := newTestMessage(nil)
+
+	t.Run("unhealthy provider is skipped", func(t *testing.T) {
+		t.Parallel()
+
+		primary := &stubProvider{name: "primary", errs: []error{errTestTransient, nil}}
+		backup := &stubProvider{name: "backup"}
+
+		failover, _ := providers.NewFailover(providers.FailoverConfig{Cooldown: time.Hour, IsTransient: isTestTransient}, primary, backup)
+
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+
+		assert.Equal(t, 1, primary.sendCalls())
+		assert.Equal(t, 2, backup.sendCalls())
+
+		health := failover.Health()
+		if !assert.Len(t, health, 2) {
+			t.FailNow()
+		}
+
+		assert.Equal(t, mailing.MailProviderName("primary"), health[0].Name)
+		assert.False(t, health[0].Healthy)
+		assert.Equal(t, 1, health[0].ConsecutiveFailures)
+		assert.ErrorIs(t, health[0].LastError, errTestTransient)
+		assert.True(t, health[1].Healthy)
+		assert.Equal(t, 2, health[1].Delivered)
+	})
+
+	t.Run("unhealthy provider is used as last resort", func(t *testing.T) {
+		t.Parallel()
+
+		primary := &stubProvider{name: "primary", errs: []error{errTestTransient, nil}}
+		backup := &stubProvider{name: "backup", errs: []error{errTestTransient}}
+
+		failover, _ := providers.NewFailover(providers.FailoverConfig{Cooldown: time.Hour, IsTransient: isTestTransient}, primary, backup)
+
+		assert.ErrorIs(t, failover.Send(context.Background(), &msg), mailErrors.ErrAllProvidersFailed)
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+		assert.Equal(t, mailing.MailProviderName("primary"), failover.LastDeliveredBy())
+	})
+
+	t.Run("provider is healthy after cooldown", func(t *testing.T) {
+		t.Parallel()
+
+		primary := &stubProvider{name: "primary", errs: []error{errTestTransient, nil}}
+		backup := &stubProvider{name: "backup"}
+
+		failover, _ := providers.NewFailover(providers.FailoverConfig{Cooldown: time.Millisecond, IsTransient: isTestTransient}, primary, backup)
+
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+		time.Sleep(2 * time.Millisecond)
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+
+		assert.Equal(t, 2, primary.sendCalls())
+		assert.Equal(t, mailing.MailProviderName("primary"), failover.LastDeliveredBy())
+		assert.True(t, failover.Health()[0].Healthy)
+	})
+
+	t.Run("failure threshold", func(t *testing.T) {
+		t.Parallel()
+
+		primary := &stubProvider{name: "primary", errs: []error{errTestTransient}}
+		backup := &stubProvider{name: "backup"}
+
+		failover, _ := providers.NewFailover(providers.FailoverConfig{Cooldown: time.Hour, FailureThreshold: 2, IsTransient: isTestTransient}, primary, backup)
+
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+		assert.True(t, failover.Health()[0].Healthy)
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+		assert.False(t, failover.Health()[0].Healthy)
+		assert.NoError(t, failover.Send(context.Background(), &msg))
+
+		assert.Equal(t, 2, primary.sendCalls())
+	})
+}
+
+func TestNewFailover(t *testing.T) {
+	t.Parallel()
+
+	_, err := providers.NewFailover(providers.FailoverConfig{})
+	assert.ErrorIs(t, err, mailErrors.ErrNoProviders)
+}
@@ -2,16 +2,28 @@ package providers
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
 	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
 )
 
+// emlSequence orders files of messages sent at the same time.
+var emlSequence uint64
+
+// File provider appends messages text representation into a file or, if the file path is a directory
+// (existing one or ending with path separator), writes every message into its own .eml file.
 type File struct {
 	providerCfg mailing.MailProviderConfigInterface
+	dir         string
 }
 
 func NewFileProvider(providerCfg mailing.MailProviderConfigInterface) (File, error) {
@@ -19,6 +31,14 @@ func NewFileProvider(providerCfg mailing.MailProviderConfigInterface) (File, err
 		return File{}, fmt.Errorf("file provider config validation error: %w", err)
 	}
 
+	if path := providerCfg.GetHostPort().GetHost(); isDirPath(path) {
+		if err := os.MkdirAll(path, 0o700); err != nil { // nolint: gomnd
+			return File{}, fmt.Errorf("email directory create error: %w", err)
+		}
+
+		return File{providerCfg: providerCfg, dir: path}, nil
+	}
+
 	if !fileExists(providerCfg.GetHostPort().GetHost()) {
 		if err := createFile(providerCfg.GetHostPort().GetHost()); err != nil {
 			return File{}, fmt.Errorf("email file create error: %w", err)
@@ -36,23 +56,86 @@ func (f File) Name() mailing.MailProviderName {
 	return mailing.MailProviderFile
 }
 
-func (f File) Send(_ context.Context, msg contracts.MessageInterface) error {
+func (f File) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := f.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (f File) SendWithResult(_ context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	if f.dir != "" {
+		return f.writeEML(msg)
+	}
+
 	file, err := os.OpenFile(
 		f.providerCfg.GetHostPort().GetHost(),
 		os.O_APPEND|os.O_WRONLY|os.O_CREATE,
 		0o600, // nolint: gomnd
 	)
 	if err != nil {
-		return fmt.Errorf("file open on email send error: %w", err)
+		return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("file open on email send error: %w", err)
 	}
 
 	defer file.Close()
 
 	if _, err = file.WriteString(msg.String() + "\n"); err != nil {
-		return fmt.Errorf("file write on email send error: %w", err)
+		return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("file write on email send error: %w", err)
 	}
 
-	return nil
+	return newSendResult(f.Name(), "", msg), nil
+}
+
+// writeEML writes message into a new .eml file. File is written under temporary name and renamed then,
+// so directory readers never see partially written messages.
+func (f File) writeEML(msg contracts.MessageInterface) (contracts.SendResult, error) {
+	messageID := eml.NewMessageID(msg.GetFrom().GetDomain())
+
+	tmp, err := os.CreateTemp(f.dir, ".eml-*.tmp")
+	if err != nil {
+		return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("eml file create error: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if err := eml.Write(tmp, msg, eml.Options{MessageID: messageID, IncludeBcc: true}); err != nil {
+		_ = tmp.Close()
+
+		return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("eml file write error: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("eml file write error: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(f.dir, newEMLFileName())); err != nil {
+		return contracts.SendResult{Provider: f.Name()}, fmt.Errorf("eml file rename error: %w", err)
+	}
+
+	return newSendResult(f.Name(), messageID, msg), nil
+}
+
+// newEMLFileName returns unique file name, sortable in send order within the process.
+func newEMLFileName() string {
+	b := make([]byte, 4) // nolint: gomnd
+	_, _ = rand.Read(b)
+
+	return fmt.Sprintf(
+		"%s-%06d-%s.eml",
+		time.Now().UTC().Format("20060102T150405.000000000Z"),
+		atomic.AddUint64(&emlSequence, 1),
+		hex.EncodeToString(b),
+	)
+}
+
+// isDirPath checks if path is an existing directory or ends with path separator.
+func isDirPath(path string) bool {
+	if strings.HasSuffix(path, string(os.PathSeparator)) || strings.HasSuffix(path, "/") {
+		return true
+	}
+
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
 }
 
 func createFile(filePath string) error {
@@ -0,0 +1,108 @@
+package providers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/eml"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_Send(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "emails.txt")
+
+	provider, err := providers.NewFileProvider(mailing.FileConfig{FilePath: path})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	for i := 0; i < 2; i++ {
+		if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+			t.FailNow()
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, msg.String()+"\n"+msg.String()+"\n", string(content))
+}
+
+func TestFile_SendEML(t *testing.T) {
+	type testCase struct {
+		name string
+		dir  func(t *testing.T) string
+	}
+
+	tcs := []testCase{
+		{name: "existing directory", dir: func(t *testing.T) string { return t.TempDir() }},
+		{name: "new directory", dir: func(t *testing.T) string { return filepath.Join(t.TempDir(), "emails") + "/" }},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := tc.dir(t)
+
+			provider, err := providers.NewFileProvider(mailing.FileConfig{FilePath: dir})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			subjects := []string{"first", "second", "third"}
+			ids := make([]string, 0, len(subjects))
+
+			for _, subject := range subjects {
+				msg := newTestMessage(testAttachments)
+				msg.Subject = subject
+
+				res, err := provider.SendWithResult(context.Background(), &msg)
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+
+				ids = append(ids, res.MessageID)
+			}
+
+			files, err := filepath.Glob(filepath.Join(dir, "*"))
+			if !assert.NoError(t, err) || !assert.Len(t, files, len(subjects)) {
+				t.FailNow()
+			}
+
+			sort.Strings(files)
+
+			for i, file := range files {
+				assert.Equal(t, ".eml", filepath.Ext(file))
+
+				content, err := os.ReadFile(file)
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+
+				parsed, err := eml.Unmarshal(content)
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+
+				assert.Equal(t, subjects[i], parsed.Subject)
+				assert.Contains(t, string(content), "Message-ID: <"+ids[i]+">")
+				assert.Len(t, parsed.Attachments, len(testAttachments))
+			}
+		})
+	}
+}
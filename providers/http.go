@@ -0,0 +1,113 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+// configuredHost returns api host from provider config or empty string if it is not set.
+func configuredHost(providerCfg mailing.MailProviderConfigInterface) string {
+	hp := providerCfg.GetHostPort()
+	if hp == nil || hp.IsEmpty() {
+		return ""
+	}
+
+	return hp.String()
+}
+
+// hostRewriteTransport sends requests to configured host instead of the one
+// hard-coded in provider client library.
+type hostRewriteTransport struct {
+	base *url.URL
+	next http.RoundTripper
+}
+
+func newHostRewriteTransport(host string) (hostRewriteTransport, error) {
+	base, err := url.Parse(host)
+	if err != nil {
+		return hostRewriteTransport{}, fmt.Errorf("api host parse error: %w", err)
+	}
+
+	return hostRewriteTransport{base: base, next: http.DefaultTransport}, nil
+}
+
+func (t hostRewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.base.Scheme
+	r.URL.Host = t.base.Host
+	r.Host = t.base.Host
+
+	return t.next.RoundTrip(r) // nolint: wrapcheck
+}
+
+// contextTransport sends requests with ctx, for client libraries without context support.
+type contextTransport struct {
+	ctx  context.Context // nolint: containedctx
+	next http.RoundTripper
+}
+
+func newContextTransport(ctx context.Context, next http.RoundTripper) contextTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return contextTransport{ctx: ctx, next: next}
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(t.ctx)) // nolint: wrapcheck
+}
+
+// withSendTimeout limits context with provider send timeout if it is set.
+func withSendTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// newJSONRequest returns POST request to url with json encoded in as body, which is returned too for request signing.
+// Encoding errors are not going to disappear on retry, so they are permanent.
+func newJSONRequest(ctx context.Context, url string, in interface{}) (*http.Request, []byte, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, nil, mailErrors.Permanent(0, fmt.Errorf("request encode error: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, mailErrors.Permanent(0, fmt.Errorf("request build error: %w", err))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	return req, body, nil
+}
+
+// doRequest sends req and returns response with its read body. Transport errors are classified with FromTransport.
+func doRequest(client *http.Client, req *http.Request) (*http.Response, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, mailErrors.FromTransport(err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, mailErrors.FromTransport(fmt.Errorf("response read error: %w", err))
+	}
+
+	return resp, body, nil
+}
@@ -0,0 +1,18 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package providers
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes exclusive advisory lock of the file, waiting for other processes to release it.
+func lockFile(file *os.File) (func(), error) {
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		return nil, err // nolint: wrapcheck
+	}
+
+	return func() { _ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN) }, nil
+}
@@ -0,0 +1,18 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package providers
+
+import (
+	"os"
+	"sync"
+)
+
+// fileLock serializes writes within the process, files are not locked for other processes on systems without flock.
+var fileLock sync.Mutex
+
+func lockFile(_ *os.File) (func(), error) {
+	fileLock.Lock()
+
+	return fileLock.Unlock, nil
+}
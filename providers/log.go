@@ -25,8 +25,14 @@ func (o LogProvider) Name() mailing.MailProviderName {
 	return mailing.MailProviderLogs
 }
 
-func (o LogProvider) Send(_ context.Context, msg contracts.MessageInterface) error {
+func (o LogProvider) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (o LogProvider) SendWithResult(_ context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
 	o.logger.Printf("email by [%s]:\n%s", o.Name(), msg.String())
 
-	return nil
+	return newSendResult(o.Name(), "", msg), nil
 }
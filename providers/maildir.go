@@ -0,0 +1,112 @@
+package providers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+)
+
+// maildirSequence is a per process delivery counter, part of unique maildir file names.
+var maildirSequence uint64
+
+// Maildir delivers messages into local maildir: message is written into tmp subdirectory
+// and atomically moved into new one, so mail readers never see partially written messages.
+type Maildir struct {
+	dir string
+}
+
+func NewMaildir(providerCfg mailing.MailProviderConfigInterface) (Maildir, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return Maildir{}, fmt.Errorf("maildir provider config validation error: %w", err)
+	}
+
+	dir := providerCfg.GetHostPort().GetHost()
+
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil { // nolint: gomnd
+			return Maildir{}, fmt.Errorf("maildir %s create error: %w", sub, err)
+		}
+	}
+
+	return Maildir{dir: dir}, nil
+}
+
+func (o Maildir) Name() mailing.MailProviderName {
+	return MailProviderMaildir
+}
+
+func (o Maildir) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (o Maildir) SendWithResult(_ context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	messageID := eml.NewMessageID(msg.GetFrom().GetDomain())
+	name := newMaildirName()
+	tmpPath := filepath.Join(o.dir, "tmp", name)
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) // nolint: gomnd
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("maildir file create error: %w", err)
+	}
+
+	defer os.Remove(tmpPath)
+
+	if err := eml.Write(file, msg, eml.Options{MessageID: messageID}); err != nil {
+		_ = file.Close()
+
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("maildir file write error: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("maildir file sync error: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("maildir file write error: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, filepath.Join(o.dir, "new", name)); err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("maildir file deliver error: %w", err)
+	}
+
+	return newSendResult(o.Name(), messageID, msg), nil
+}
+
+// newMaildirName returns unique maildir file name "<seconds>.M<microseconds>P<pid>Q<sequence>R<random>.<host>".
+func newMaildirName() string {
+	now := time.Now()
+	b := make([]byte, 8) // nolint: gomnd
+	_, _ = rand.Read(b)
+
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+
+	// slash and colon are not allowed in maildir file names
+	host = strings.NewReplacer("/", `\057`, ":", `\072`).Replace(host)
+
+	return fmt.Sprintf(
+		"%d.M%dP%dQ%dR%s.%s",
+		now.Unix(),
+		now.Nanosecond()/int(time.Microsecond),
+		os.Getpid(),
+		atomic.AddUint64(&maildirSequence, 1),
+		hex.EncodeToString(b),
+		host,
+	)
+}
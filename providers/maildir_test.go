@@ -0,0 +1,93 @@
+package providers_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/spacetab-io/mails-go/eml"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaildir_Send(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "Maildir")
+
+	provider, err := providers.NewMaildir(providers.MaildirConfig{Dir: dir})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	const senders = 20
+
+	wg := sync.WaitGroup{}
+	ids := make(chan string, senders)
+
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			msg := newTestMessage(testAttachments)
+			msg.Subject = fmt.Sprintf("message %02d", i)
+
+			res, err := provider.SendWithResult(context.Background(), &msg)
+			assert.NoError(t, err)
+
+			ids <- res.MessageID
+		}(i)
+	}
+
+	wg.Wait()
+	close(ids)
+
+	tmp, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	assert.NoError(t, err)
+	assert.Empty(t, tmp)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "new"))
+	if !assert.NoError(t, err) || !assert.Len(t, entries, senders) {
+		t.FailNow()
+	}
+
+	subjects := make([]string, 0, senders)
+
+	for _, e := range entries {
+		content, err := os.ReadFile(filepath.Join(dir, "new", e.Name()))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		msg, err := eml.Unmarshal(content)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		subjects = append(subjects, msg.Subject)
+	}
+
+	sort.Strings(subjects)
+
+	for i, subject := range subjects {
+		assert.Equal(t, fmt.Sprintf("message %02d", i), subject)
+	}
+
+	assert.Len(t, collect(ids), senders)
+}
+
+// collect returns unique channel values.
+func collect(ch <-chan string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for v := range ch {
+		set[v] = struct{}{}
+	}
+
+	return set
+}
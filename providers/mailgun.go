@@ -1,13 +1,17 @@
 package providers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/mailgun/mailgun-go/v4"
 	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
 	"github.com/spacetab-io/configuration-structs-go/v2/mime"
 	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
 )
 
 type Mailgun struct {
@@ -31,12 +35,24 @@ func (o Mailgun) Name() mailing.MailProviderName {
 }
 
 func (o Mailgun) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (o Mailgun) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
 	tos := make([]string, 0)
 	for _, to := range msg.GetTo().GetList() {
 		tos = append(tos, to.String())
 	}
 
-	message := o.client.NewMessage(msg.GetFrom().String(), msg.GetSubject(), string(msg.GetBody()), tos...)
+	// mailgun sends text part as is, so html only message has its html as text part.
+	text := msg.GetBody()
+	if msg.IsAlternative() {
+		text = msg.GetPlainText()
+	}
+
+	message := o.client.NewMessage(msg.GetFrom().String(), msg.GetSubject(), string(text), tos...)
 
 	if !msg.GetCc().IsEmpty() {
 		for _, cc := range msg.GetCc().GetList() {
@@ -54,21 +70,41 @@ func (o Mailgun) Send(ctx context.Context, msg contracts.MessageInterface) error
 		message.SetReplyTo(msg.GetReplyTo().String())
 	}
 
-	if msg.GetMimeType() == mime.TextHTML {
-		message.SetHtml(string(msg.GetBody()))
+	if msg.IsAlternative() || msg.GetMimeType() == mime.TextHTML {
+		message.SetHtml(string(msg.GetHTML()))
+	}
+
+	// mailgun detects attachment content type by file name.
+	for _, att := range msg.GetAttachments().GetList() {
+		if att.GetAttachMethod() == contracts.AttachMethodInline {
+			message.AddReaderInline(contracts.AttachmentFileName(att), io.NopCloser(bytes.NewReader(att.GetContent())))
+
+			continue
+		}
+
+		message.AddBufferAttachment(contracts.AttachmentFileName(att), att.GetContent())
 	}
 
-	if o.providerCfg.GetDKIMPrivateKey() != nil {
+	if k := o.providerCfg.GetDKIMPrivateKey(); k != nil && *k != "" {
 		message.SetDKIM(true)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, o.providerCfg.GetSendTimeout())
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
 
 	defer cancel()
 
-	if _, _, err := o.client.Send(ctx, message); err != nil {
-		return fmt.Errorf("%s send message error: %w", o.Name(), err)
+	_, id, err := o.client.Send(ctx, message)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("%s send message error: %w", o.Name(), classifyMailgunError(err))
+	}
+
+	return newSendResult(o.Name(), strings.Trim(id, "<>"), msg), nil
+}
+
+func classifyMailgunError(err error) error {
+	if status := mailgun.GetStatusFromErr(err); status > 0 {
+		return mailErrors.FromHTTPStatus(status, err)
 	}
 
-	return nil
+	return mailErrors.FromTransport(err)
 }
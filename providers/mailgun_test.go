@@ -0,0 +1,194 @@
+package providers_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+type formField struct {
+	name     string
+	filename string
+	value    string
+}
+
+func readFormFields(t *testing.T, req recordedRequest) []formField {
+	t.Helper()
+
+	_, params, err := mime.ParseMediaType(req.header.Get("Content-Type"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	fields := make([]formField, 0)
+	mr := multipart.NewReader(bytes.NewReader(req.body), params["boundary"])
+
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		value, err := io.ReadAll(p)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		fields = append(fields, formField{name: p.FormName(), filename: p.FileName(), value: string(value)})
+	}
+
+	return fields
+}
+
+func TestMailgun_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		in   contracts.Message
+		exp  []formField
+	}
+
+	baseFields := []formField{
+		{name: "from", value: `"From" <from@spacetab.io>`},
+		{name: "subject", value: "Test email"},
+		{name: "text", value: "<p>test email content</p>"},
+		{name: "html", value: "<p>test email content</p>"},
+		{name: "to", value: `"To" <to@spacetab.io>`},
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			in:   newTestMessage(nil),
+			exp:  baseFields,
+		},
+		{
+			name: "inline and file attachments",
+			in:   newTestMessage(testAttachments),
+			exp: append(append([]formField{}, baseFields...),
+				formField{name: "inline", filename: "logo.png", value: "png"},
+				formField{name: "attachment", filename: "report.pdf", value: "pdf"},
+			),
+		},
+		{
+			name: "html and plain text",
+			in:   newAlternativeTestMessage(),
+			exp: []formField{
+				{name: "from", value: `"From" <from@spacetab.io>`},
+				{name: "subject", value: "Test email"},
+				{name: "text", value: "test email content"},
+				{name: "html", value: "<p>test email content</p>"},
+				{name: "to", value: `"To" <to@spacetab.io>`},
+			},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusOK, "application/json", `{"id":"<abc123@spacetab.io>","message":"Queued. Thank you."}`)
+
+			provider, err := providers.NewMailgun(apiTestConfig{
+				MailProviderConfigInterface: mailing.MailgunConfig{Domain: "spacetab.io", Key: "key"},
+				host:                        api.server.URL + "/v3",
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in
+			if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, http.MethodPost, requests[0].method)
+			assert.Equal(t, "/v3/spacetab.io/messages", requests[0].path)
+			assert.Equal(t, tc.exp, readFormFields(t, requests[0]))
+		})
+	}
+}
+
+func TestMailgun_SendErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		status   int
+		response string
+		exp      error
+	}
+
+	tcs := []testCase{
+		{name: "rate limited", status: http.StatusTooManyRequests, response: `{"message":"too many requests"}`, exp: mailErrors.ErrTemporary},
+		{name: "server error", status: http.StatusInternalServerError, response: `{"message":"internal error"}`, exp: mailErrors.ErrTemporary},
+		{name: "bad request", status: http.StatusBadRequest, response: `{"message":"'to' parameter is not a valid address"}`, exp: mailErrors.ErrPermanent},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "application/json", tc.response)
+
+			provider, err := providers.NewMailgun(apiTestConfig{
+				MailProviderConfigInterface: mailing.MailgunConfig{Domain: "spacetab.io", Key: "key"},
+				host:                        api.server.URL + "/v3",
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestMailgun_SendWithResult(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusOK, "application/json", `{"id":"<abc123@spacetab.io>","message":"Queued. Thank you."}`)
+
+	provider, err := providers.NewMailgun(apiTestConfig{
+		MailProviderConfigInterface: mailing.MailgunConfig{Domain: "spacetab.io", Key: "key"},
+		host:                        api.server.URL + "/v3",
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	res, err := provider.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, provider.Name(), res.Provider)
+	assert.Equal(t, "abc123@spacetab.io", res.MessageID)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", MessageID: "abc123@spacetab.io", Status: "accepted"}}, res.Accepted)
+}
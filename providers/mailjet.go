@@ -0,0 +1,258 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const (
+	mailjetSendPath      = "/v3.1/send"
+	mailjetStatusSuccess = "success"
+)
+
+// MailjetOptions are Mailjet settings of a single message.
+type MailjetOptions struct {
+	// CustomID is a message id of the sender, which is returned in Mailjet events.
+	CustomID string
+	// EventPayload is a payload returned in Mailjet events.
+	EventPayload string
+	// TemplateID is an id of stored template used instead of message body.
+	TemplateID int
+	// Variables are template variables.
+	Variables map[string]interface{}
+}
+
+type mailjetOptionsKey struct{}
+
+// WithMailjetOptions returns context carrying Mailjet settings of sent message.
+func WithMailjetOptions(ctx context.Context, opts MailjetOptions) context.Context {
+	return context.WithValue(ctx, mailjetOptionsKey{}, opts)
+}
+
+// Mailjet sends messages with Mailjet send api v3.1.
+type Mailjet struct {
+	client      *http.Client
+	endpoint    string
+	providerCfg MailjetConfigInterface
+}
+
+func NewMailjet(providerCfg mailing.MailProviderConfigInterface) (Mailjet, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return Mailjet{}, fmt.Errorf("mailjet provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(MailjetConfigInterface)
+	if !ok {
+		return Mailjet{}, fmt.Errorf("mailjet provider config error: %T is not MailjetConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	return Mailjet{
+		client:      &http.Client{},
+		endpoint:    strings.TrimSuffix(cfg.GetHostPort().String(), "/"),
+		providerCfg: cfg,
+	}, nil
+}
+
+func (o Mailjet) Name() mailing.MailProviderName {
+	return "mailjetAPI"
+}
+
+func (o Mailjet) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+type mailjetAddress struct {
+	Email string `json:"Email"`
+	Name  string `json:"Name,omitempty"`
+}
+
+type mailjetAttachment struct {
+	ContentType   string `json:"ContentType"`
+	Filename      string `json:"Filename"`
+	ContentID     string `json:"ContentID,omitempty"`
+	Base64Content []byte `json:"Base64Content"`
+}
+
+type mailjetMessage struct {
+	From               *mailjetAddress        `json:"From,omitempty"`
+	To                 []mailjetAddress       `json:"To"`
+	Cc                 []mailjetAddress       `json:"Cc,omitempty"`
+	Bcc                []mailjetAddress       `json:"Bcc,omitempty"`
+	ReplyTo            *mailjetAddress        `json:"ReplyTo,omitempty"`
+	Subject            string                 `json:"Subject,omitempty"`
+	TextPart           string                 `json:"TextPart,omitempty"`
+	HTMLPart           string                 `json:"HTMLPart,omitempty"`
+	TemplateID         int                    `json:"TemplateID,omitempty"`
+	TemplateLanguage   bool                   `json:"TemplateLanguage,omitempty"`
+	Variables          map[string]interface{} `json:"Variables,omitempty"`
+	Attachments        []mailjetAttachment    `json:"Attachments,omitempty"`
+	InlinedAttachments []mailjetAttachment    `json:"InlinedAttachments,omitempty"`
+	CustomID           string                 `json:"CustomID,omitempty"`
+	EventPayload       string                 `json:"EventPayload,omitempty"`
+	CustomCampaign     string                 `json:"CustomCampaign,omitempty"`
+}
+
+type mailjetRequest struct {
+	Messages    []mailjetMessage `json:"Messages"`
+	SandboxMode bool             `json:"SandboxMode,omitempty"`
+}
+
+type mailjetRecipientStatus struct {
+	Email       string `json:"Email"`
+	MessageUUID string `json:"MessageUUID"`
+	MessageID   int64  `json:"MessageID"`
+}
+
+type mailjetError struct {
+	ErrorCode      string   `json:"ErrorCode"`
+	StatusCode     int      `json:"StatusCode"`
+	ErrorMessage   string   `json:"ErrorMessage"`
+	ErrorRelatedTo []string `json:"ErrorRelatedTo"`
+}
+
+type mailjetMessageStatus struct {
+	Status string                   `json:"Status"`
+	To     []mailjetRecipientStatus `json:"To"`
+	Cc     []mailjetRecipientStatus `json:"Cc"`
+	Bcc    []mailjetRecipientStatus `json:"Bcc"`
+	Errors []mailjetError           `json:"Errors"`
+}
+
+type mailjetResponse struct {
+	Messages []mailjetMessageStatus `json:"Messages"`
+	// StatusCode and ErrorMessage are set for errors of whole request, e.g. authentication ones.
+	StatusCode   int    `json:"StatusCode"`
+	ErrorMessage string `json:"ErrorMessage"`
+}
+
+func (o Mailjet) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	req, _, err := newJSONRequest(ctx, o.endpoint+mailjetSendPath, mailjetRequest{
+		Messages:    []mailjetMessage{o.toMailjetMessage(ctx, msg)},
+		SandboxMode: o.providerCfg.GetSandboxMode(),
+	})
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mailjet request error: %w", err)
+	}
+
+	req.SetBasicAuth(o.providerCfg.GetUsername(), o.providerCfg.GetPassword())
+
+	resp, body, err := doRequest(o.client, req)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mailjet email send error: %w", err)
+	}
+
+	var mjResp mailjetResponse
+
+	_ = json.Unmarshal(body, &mjResp)
+
+	if resp.StatusCode != http.StatusOK || len(mjResp.Messages) == 0 || mjResp.Messages[0].Status != mailjetStatusSuccess {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mailjet send message error: %w", classifyMailjetError(resp.StatusCode, mjResp))
+	}
+
+	return toMailjetResult(o.Name(), mjResp.Messages[0]), nil
+}
+
+// toMailjetResult maps recipient statuses into send result. Id of the first recipient message is result message id.
+func toMailjetResult(name mailing.MailProviderName, status mailjetMessageStatus) contracts.SendResult {
+	res := contracts.SendResult{Provider: name, SentAt: time.Now()}
+
+	for _, list := range [][]mailjetRecipientStatus{status.To, status.Cc, status.Bcc} {
+		for _, r := range list {
+			messageID := strconv.FormatInt(r.MessageID, 10)
+			if res.MessageID == "" {
+				res.MessageID = messageID
+			}
+
+			res.Accepted = append(res.Accepted, contracts.RecipientStatus{Email: r.Email, MessageID: messageID, Status: recipientStatusAccepted})
+		}
+	}
+
+	return res
+}
+
+// classifyMailjetError classifies Mailjet error response by http status and the first message error.
+func classifyMailjetError(status int, resp mailjetResponse) error {
+	reason := resp.ErrorMessage
+	if len(resp.Messages) != 0 && len(resp.Messages[0].Errors) != 0 {
+		mjErr := resp.Messages[0].Errors[0]
+		reason = fmt.Sprintf("%s %s %v", mjErr.ErrorCode, mjErr.ErrorMessage, mjErr.ErrorRelatedTo)
+	}
+
+	return mailErrors.FromHTTPStatus(status, fmt.Errorf("%d mailjet error: %s", status, reason)) // nolint: goerr113
+}
+
+func (o Mailjet) toMailjetMessage(ctx context.Context, msg contracts.MessageInterface) mailjetMessage {
+	opts, _ := ctx.Value(mailjetOptionsKey{}).(MailjetOptions)
+
+	mm := mailjetMessage{
+		To:               toMailjetAddresses(msg.GetTo()),
+		Cc:               toMailjetAddresses(msg.GetCc()),
+		Bcc:              toMailjetAddresses(msg.GetBcc()),
+		Subject:          msg.GetSubject(),
+		TemplateID:       opts.TemplateID,
+		TemplateLanguage: o.providerCfg.GetTemplateLanguage(),
+		Variables:        opts.Variables,
+		CustomID:         opts.CustomID,
+		EventPayload:     opts.EventPayload,
+		CustomCampaign:   o.providerCfg.GetCustomCampaign(),
+	}
+
+	if !msg.GetFrom().IsEmpty() {
+		mm.From = &mailjetAddress{Email: msg.GetFrom().GetEmail(), Name: msg.GetFrom().GetName()}
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		mm.ReplyTo = &mailjetAddress{Email: msg.GetReplyTo().GetEmail(), Name: msg.GetReplyTo().GetName()}
+	}
+
+	switch {
+	case msg.IsAlternative():
+		mm.HTMLPart, mm.TextPart = string(msg.GetHTML()), string(msg.GetPlainText())
+	case msg.GetMimeType() == mime.TextHTML:
+		mm.HTMLPart = string(msg.GetBody())
+	default:
+		mm.TextPart = string(msg.GetBody())
+	}
+
+	for _, att := range msg.GetAttachments().GetList() {
+		ma := mailjetAttachment{ContentType: att.GetMimeType(), Filename: contracts.AttachmentFileName(att), Base64Content: att.GetContent()}
+
+		if att.GetAttachMethod() == contracts.AttachMethodInline {
+			ma.ContentID = contracts.AttachmentFileName(att)
+			mm.InlinedAttachments = append(mm.InlinedAttachments, ma)
+		} else {
+			mm.Attachments = append(mm.Attachments, ma)
+		}
+	}
+
+	return mm
+}
+
+func toMailjetAddresses(list mailing.MailAddressListInterface) []mailjetAddress {
+	if list == nil || list.IsEmpty() {
+		return nil
+	}
+
+	addrs := make([]mailjetAddress, 0, len(list.GetList()))
+	for _, addr := range list.GetList() {
+		addrs = append(addrs, mailjetAddress{Email: addr.GetEmail(), Name: addr.GetName()})
+	}
+
+	return addrs
+}
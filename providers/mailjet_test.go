@@ -0,0 +1,189 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+const mailjetSuccess = `{"Messages":[{"Status":"success","CustomID":"","To":[
+	{"Email":"to@spacetab.io","MessageUUID":"123e4567-e89b-12d3-a456-426614174000","MessageID":288230376161011640,"MessageHref":""}
+],"Cc":[],"Bcc":[]}]}`
+
+func TestMailjet_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		cfg  providers.MailjetConfig
+		ctx  context.Context
+		in   func() contracts.Message
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			cfg:  providers.MailjetConfig{APIKey: "key", SecretKey: "secret"},
+			ctx:  context.Background(),
+			in:   func() contracts.Message { return newTestMessage(nil) },
+			exp: `{"Messages":[{
+				"From":{"Email":"from@spacetab.io","Name":"From"},"To":[{"Email":"to@spacetab.io","Name":"To"}],
+				"Subject":"Test email","HTMLPart":"<p>test email content</p>"
+			}]}`,
+		},
+		{
+			name: "inline and file attachments",
+			cfg:  providers.MailjetConfig{APIKey: "key", SecretKey: "secret"},
+			ctx:  context.Background(),
+			in:   func() contracts.Message { return newTestMessage(testAttachments) },
+			exp: `{"Messages":[{
+				"From":{"Email":"from@spacetab.io","Name":"From"},"To":[{"Email":"to@spacetab.io","Name":"To"}],
+				"Subject":"Test email","HTMLPart":"<p>test email content</p>",
+				"Attachments":[{"ContentType":"application/pdf","Filename":"report.pdf","Base64Content":"cGRm"}],
+				"InlinedAttachments":[{"ContentType":"image/png","Filename":"logo.png","ContentID":"logo.png","Base64Content":"cG5n"}]
+			}]}`,
+		},
+		{
+			name: "template with custom id, event payload and sandbox",
+			cfg:  providers.MailjetConfig{APIKey: "key", SecretKey: "secret", CustomCampaign: "welcome", TemplateLanguage: true, SandboxMode: true},
+			ctx: providers.WithMailjetOptions(context.Background(), providers.MailjetOptions{
+				CustomID:     "order-1",
+				EventPayload: `{"order":1}`,
+				TemplateID:   4321,
+				Variables:    map[string]interface{}{"name": "Spacetab"},
+			}),
+			in: func() contracts.Message {
+				msg := newAlternativeTestMessage()
+				msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io"}}
+				msg.Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+				msg.ReplyTo = mailing.MailAddress{Email: "reply@spacetab.io", Name: "Reply"}
+
+				return msg
+			},
+			exp: `{"Messages":[{
+				"From":{"Email":"from@spacetab.io","Name":"From"},"To":[{"Email":"to@spacetab.io","Name":"To"}],
+				"Cc":[{"Email":"cc@spacetab.io"}],"Bcc":[{"Email":"bcc@spacetab.io"}],"ReplyTo":{"Email":"reply@spacetab.io","Name":"Reply"},
+				"Subject":"Test email","HTMLPart":"<p>test email content</p>","TextPart":"test email content",
+				"TemplateID":4321,"TemplateLanguage":true,"Variables":{"name":"Spacetab"},
+				"CustomID":"order-1","EventPayload":"{\"order\":1}","CustomCampaign":"welcome"
+			}],"SandboxMode":true}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusOK, "application/json", mailjetSuccess)
+
+			cfg := tc.cfg
+			cfg.Endpoint = api.server.URL
+
+			provider, err := providers.NewMailjet(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in()
+			if !assert.NoError(t, provider.Send(tc.ctx, &msg)) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, http.MethodPost, requests[0].method)
+			assert.Equal(t, "/v3.1/send", requests[0].path)
+			assert.Equal(t, "Basic a2V5OnNlY3JldA==", requests[0].header.Get("Authorization"))
+			assert.JSONEq(t, tc.exp, string(requests[0].body))
+		})
+	}
+}
+
+func TestMailjet_SendErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		status   int
+		response string
+		exp      error
+	}
+
+	tcs := []testCase{
+		{
+			name:     "authentication failure",
+			status:   http.StatusUnauthorized,
+			response: `{"ErrorIdentifier":"a1b2","StatusCode":401,"ErrorMessage":"API key authentication/authorization failure."}`,
+			exp:      mailErrors.ErrPermanent,
+		},
+		{
+			name:   "invalid recipient",
+			status: http.StatusBadRequest,
+			response: `{"Messages":[{"Status":"error","Errors":[
+				{"ErrorIdentifier":"c3d4","ErrorCode":"mj-0013","StatusCode":400,"ErrorMessage":"\"to\" is an invalid email address.","ErrorRelatedTo":["To[0].Email"]}
+			]}]}`,
+			exp: mailErrors.ErrPermanent,
+		},
+		{name: "rate limited", status: http.StatusTooManyRequests, response: `{"StatusCode":429,"ErrorMessage":"Too many requests"}`, exp: mailErrors.ErrTemporary},
+		{name: "server error", status: http.StatusInternalServerError, response: ``, exp: mailErrors.ErrTemporary},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "application/json", tc.response)
+
+			provider, err := providers.NewMailjet(providers.MailjetConfig{APIKey: "key", SecretKey: "secret", Endpoint: api.server.URL})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestMailjet_SendWithResult(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusOK, "application/json", `{"Messages":[{"Status":"success","To":[
+		{"Email":"to@spacetab.io","MessageUUID":"u-1","MessageID":1001}
+	],"Cc":[
+		{"Email":"cc@spacetab.io","MessageUUID":"u-2","MessageID":1002}
+	]}]}`)
+
+	provider, err := providers.NewMailjet(providers.MailjetConfig{APIKey: "key", SecretKey: "secret", Endpoint: api.server.URL})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+	msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io"}}
+
+	res, err := provider.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, provider.Name(), res.Provider)
+	assert.Equal(t, "1001", res.MessageID)
+	assert.Equal(t, []contracts.RecipientStatus{
+		{Email: "to@spacetab.io", MessageID: "1001", Status: "accepted"},
+		{Email: "cc@spacetab.io", MessageID: "1002", Status: "accepted"},
+	}, res.Accepted)
+}
@@ -2,12 +2,16 @@ package providers
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mattbaird/gochimp"
 	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
 	"github.com/spacetab-io/configuration-structs-go/v2/mime"
 	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
 )
 
 type Mandrill struct {
@@ -29,6 +33,15 @@ func NewMandrill(providerCfg mailing.MailProviderConfigInterface) (Mandrill, err
 		api.Timeout = providerCfg.GetSendTimeout()
 	}
 
+	if host := configuredHost(providerCfg); host != "" {
+		transport, err := newHostRewriteTransport(host)
+		if err != nil {
+			return Mandrill{}, fmt.Errorf("mandrill client init error: %w", err)
+		}
+
+		api.Transport = transport
+	}
+
 	return Mandrill{mandrillAPI: api, providerCfg: providerCfg}, nil
 }
 
@@ -36,7 +49,13 @@ func (o Mandrill) Name() mailing.MailProviderName {
 	return "mandrillAPI"
 }
 
-func (o Mandrill) Send(_ context.Context, msg contracts.MessageInterface) error {
+func (o Mandrill) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (o Mandrill) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
 	tos := make([]gochimp.Recipient, 0)
 
 	for _, to := range msg.GetTo().GetList() {
@@ -73,11 +92,12 @@ func (o Mandrill) Send(_ context.Context, msg contracts.MessageInterface) error
 		To:        tos,
 	}
 
-	switch msg.GetMimeType() {
-	case mime.TextHTML:
+	switch {
+	case msg.IsAlternative():
+		message.Html = string(msg.GetHTML())
+		message.Text = string(msg.GetPlainText())
+	case msg.GetMimeType() == mime.TextHTML:
 		message.Html = string(msg.GetBody())
-	case mime.TextPlain:
-		message.Text = string(msg.GetBody())
 	default:
 		message.Text = string(msg.GetBody())
 	}
@@ -88,9 +108,80 @@ func (o Mandrill) Send(_ context.Context, msg contracts.MessageInterface) error
 		}
 	}
 
-	if _, err := o.mandrillAPI.MessageSend(message, o.providerCfg.IsAsync()); err != nil {
-		return fmt.Errorf("mandrill email send error: %w", err)
+	for _, att := range msg.GetAttachments().GetList() {
+		// inline images are referenced from html as cid:<name>.
+		if att.GetAttachMethod() == contracts.AttachMethodInline {
+			message.Images = append(message.Images, toMandrillAttachment(att))
+
+			continue
+		}
+
+		message.Attachments = append(message.Attachments, toMandrillAttachment(att))
+	}
+
+	// client library has no context support, so request gets it from per send copy of transport
+	api := *o.mandrillAPI
+	api.Transport = newContextTransport(ctx, o.mandrillAPI.Transport)
+
+	responses, err := api.MessageSend(message, o.providerCfg.IsAsync())
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mandrill email send error: %w", classifyMandrillError(err))
 	}
 
-	return nil
+	return toMandrillResult(o.Name(), responses)
+}
+
+// toMandrillResult maps mandrill per-recipient statuses. Message is rejected if none of recipients is accepted.
+func toMandrillResult(name mailing.MailProviderName, responses []gochimp.SendResponse) (contracts.SendResult, error) {
+	res := contracts.SendResult{Provider: name, SentAt: time.Now()}
+
+	for _, r := range responses {
+		status := contracts.RecipientStatus{Email: r.Email, MessageID: r.Id, Status: r.Status, Reason: r.RejectedReason}
+
+		switch r.Status {
+		case "rejected", "invalid":
+			res.Rejected = append(res.Rejected, status)
+		default:
+			res.Accepted = append(res.Accepted, status)
+		}
+	}
+
+	if len(res.Accepted) != 0 {
+		res.MessageID = res.Accepted[0].MessageID
+	}
+
+	if len(res.Accepted) == 0 && len(res.Rejected) != 0 {
+		return res, fmt.Errorf("mandrill email send error: %w", mailErrors.Permanent(0, mailErrors.ErrAllRecipientsRejected))
+	}
+
+	return res, nil
+}
+
+// classifyMandrillError classifies mandrill api error. Only GeneralError is a server side failure,
+// other api errors (invalid key, validation error, unknown template etc.) are permanent.
+func classifyMandrillError(err error) error {
+	var apiErr gochimp.MandrillError
+	if errors.As(err, &apiErr) {
+		if apiErr.Name == "GeneralError" {
+			return mailErrors.Temporary(0, err)
+		}
+
+		return mailErrors.Permanent(0, err)
+	}
+
+	// not json responses are reported by client with http status only
+	var status int
+	if _, scanErr := fmt.Sscanf(err.Error(), "request failure: HTTP %d", &status); scanErr == nil {
+		return mailErrors.FromHTTPStatus(status, err)
+	}
+
+	return mailErrors.FromTransport(err)
+}
+
+func toMandrillAttachment(att contracts.MessageAttachmentInterface) gochimp.Attachment {
+	return gochimp.Attachment{
+		Type:    att.GetMimeType(),
+		Name:    contracts.AttachmentFileName(att),
+		Content: base64.StdEncoding.EncodeToString(att.GetContent()),
+	}
 }
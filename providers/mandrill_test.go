@@ -0,0 +1,269 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMandrill_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		in   contracts.Message
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			in:   newTestMessage(nil),
+			exp: `{
+				"key":"key",
+				"async":false,
+				"message":{
+					"html":"<p>test email content</p>",
+					"subject":"Test email",
+					"from_email":"from@spacetab.io",
+					"from_name":"From",
+					"to":[{"email":"to@spacetab.io","name":"To","type":""}],
+					"track_opens":false,
+					"track_clicks":false
+				}
+			}`,
+		},
+		{
+			name: "inline and file attachments",
+			in:   newTestMessage(testAttachments),
+			exp: `{
+				"key":"key",
+				"async":false,
+				"message":{
+					"html":"<p>test email content</p>",
+					"subject":"Test email",
+					"from_email":"from@spacetab.io",
+					"from_name":"From",
+					"to":[{"email":"to@spacetab.io","name":"To","type":""}],
+					"track_opens":false,
+					"track_clicks":false,
+					"attachments":[{"type":"application/pdf","name":"report.pdf","content":"cGRm"}],
+					"images":[{"type":"image/png","name":"logo.png","content":"cG5n"}]
+				}
+			}`,
+		},
+		{
+			name: "html and plain text",
+			in:   newAlternativeTestMessage(),
+			exp: `{
+				"key":"key",
+				"async":false,
+				"message":{
+					"html":"<p>test email content</p>",
+					"text":"test email content",
+					"subject":"Test email",
+					"from_email":"from@spacetab.io",
+					"from_name":"From",
+					"to":[{"email":"to@spacetab.io","name":"To","type":""}],
+					"track_opens":false,
+					"track_clicks":false
+				}
+			}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusOK, "application/json",
+				`[{"email":"to@spacetab.io","status":"sent","_id":"abc123","reject_reason":""}]`)
+
+			provider, err := providers.NewMandrill(apiTestConfig{
+				MailProviderConfigInterface: mailing.MandrillConfig{Key: "key"},
+				host:                        api.server.URL,
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in
+			if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, http.MethodPost, requests[0].method)
+			assert.Equal(t, "/api/1.0/messages/send.json", requests[0].path)
+			assert.JSONEq(t, tc.exp, string(requests[0].body))
+		})
+	}
+}
+
+func TestMandrill_SendErrors(t *testing.T) {
+	type testCase struct {
+		name        string
+		status      int
+		contentType string
+		response    string
+		exp         error
+	}
+
+	tcs := []testCase{
+		{name: "general error", status: http.StatusInternalServerError, contentType: "application/json", response: `{"status":"error","code":-1,"name":"GeneralError","message":"unexpected error"}`, exp: mailErrors.ErrTemporary},
+		{name: "invalid key", status: http.StatusInternalServerError, contentType: "application/json", response: `{"status":"error","code":-1,"name":"Invalid_Key","message":"Invalid API key"}`, exp: mailErrors.ErrPermanent},
+		{name: "gateway error", status: http.StatusBadGateway, contentType: "text/html", response: `bad gateway`, exp: mailErrors.ErrTemporary},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, tc.contentType, tc.response)
+
+			provider, err := providers.NewMandrill(apiTestConfig{
+				MailProviderConfigInterface: mailing.MandrillConfig{Key: "key"},
+				host:                        api.server.URL,
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestMandrill_SendWithResult(t *testing.T) {
+	type expStruct struct {
+		messageID string
+		accepted  []contracts.RecipientStatus
+		rejected  []contracts.RecipientStatus
+		err       error
+	}
+
+	type testCase struct {
+		name     string
+		response string
+		exp      expStruct
+	}
+
+	tcs := []testCase{
+		{
+			name:     "all accepted",
+			response: `[{"email":"to@spacetab.io","status":"sent","_id":"abc1"},{"email":"cc@spacetab.io","status":"queued","_id":"abc2"}]`,
+			exp: expStruct{
+				messageID: "abc1",
+				accepted: []contracts.RecipientStatus{
+					{Email: "to@spacetab.io", MessageID: "abc1", Status: "sent"},
+					{Email: "cc@spacetab.io", MessageID: "abc2", Status: "queued"},
+				},
+			},
+		},
+		{
+			name:     "partially rejected",
+			response: `[{"email":"to@spacetab.io","status":"rejected","_id":"abc1","reject_reason":"hard-bounce"},{"email":"cc@spacetab.io","status":"sent","_id":"abc2"}]`,
+			exp: expStruct{
+				messageID: "abc2",
+				accepted:  []contracts.RecipientStatus{{Email: "cc@spacetab.io", MessageID: "abc2", Status: "sent"}},
+				rejected:  []contracts.RecipientStatus{{Email: "to@spacetab.io", MessageID: "abc1", Status: "rejected", Reason: "hard-bounce"}},
+			},
+		},
+		{
+			name:     "all rejected",
+			response: `[{"email":"to@spacetab.io","status":"rejected","_id":"abc1","reject_reason":"hard-bounce"},{"email":"cc@spacetab.io","status":"invalid","_id":"abc2"}]`,
+			exp: expStruct{
+				rejected: []contracts.RecipientStatus{
+					{Email: "to@spacetab.io", MessageID: "abc1", Status: "rejected", Reason: "hard-bounce"},
+					{Email: "cc@spacetab.io", MessageID: "abc2", Status: "invalid"},
+				},
+				err: mailErrors.ErrAllRecipientsRejected,
+			},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusOK, "application/json", tc.response)
+
+			provider, err := providers.NewMandrill(apiTestConfig{
+				MailProviderConfigInterface: mailing.MandrillConfig{Key: "key"},
+				host:                        api.server.URL,
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+			msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io", Name: "Cc"}}
+
+			res, err := provider.SendWithResult(context.Background(), &msg)
+			if tc.exp.err != nil {
+				assert.ErrorIs(t, err, tc.exp.err)
+				assert.ErrorIs(t, err, mailErrors.ErrPermanent)
+			} else if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, provider.Name(), res.Provider)
+			assert.Equal(t, tc.exp.messageID, res.MessageID)
+			assert.Equal(t, tc.exp.accepted, res.Accepted)
+			assert.Equal(t, tc.exp.rejected, res.Rejected)
+		})
+	}
+}
+
+func TestMandrill_SendCancel(t *testing.T) {
+	t.Parallel()
+
+	blocked := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-blocked:
+		case <-r.Context().Done():
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	provider, err := providers.NewMandrill(apiTestConfig{
+		MailProviderConfigInterface: mailing.MandrillConfig{Key: "key"},
+		host:                        server.URL,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	msg := newTestMessage(nil)
+	start := time.Now()
+
+	assert.ErrorIs(t, provider.Send(ctx, &msg), context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+	close(blocked)
+}
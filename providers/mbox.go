@@ -0,0 +1,102 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+)
+
+// mboxFromLine matches lines which have to be escaped with ">" in mboxrd format.
+var mboxFromLine = regexp.MustCompile(`(?m)^(>*From )`)
+
+// Mbox appends messages into local mbox file in mboxrd format. File is locked while message is written,
+// so it is safe to use it from several processes.
+type Mbox struct {
+	path string
+}
+
+func NewMbox(providerCfg mailing.MailProviderConfigInterface) (Mbox, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return Mbox{}, fmt.Errorf("mbox provider config validation error: %w", err)
+	}
+
+	path := providerCfg.GetHostPort().GetHost()
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0o600) // nolint: gomnd
+	if err != nil {
+		return Mbox{}, fmt.Errorf("mbox file create error: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return Mbox{}, fmt.Errorf("mbox file create error: %w", err)
+	}
+
+	return Mbox{path: path}, nil
+}
+
+func (o Mbox) Name() mailing.MailProviderName {
+	return MailProviderMbox
+}
+
+func (o Mbox) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (o Mbox) SendWithResult(_ context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	messageID := eml.NewMessageID(msg.GetFrom().GetDomain())
+
+	raw, err := eml.Marshal(msg, eml.Options{MessageID: messageID})
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mbox message build error: %w", err)
+	}
+
+	file, err := os.OpenFile(o.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) // nolint: gomnd
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mbox file open error: %w", err)
+	}
+
+	defer file.Close()
+
+	unlock, err := lockFile(file)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mbox file lock error: %w", err)
+	}
+
+	defer unlock()
+
+	if _, err := file.Write(toMboxEntry(msg.GetFrom().GetEmail(), time.Now(), raw)); err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("mbox file write error: %w", err)
+	}
+
+	return newSendResult(o.Name(), messageID, msg), nil
+}
+
+// toMboxEntry returns mboxrd entry: "From " separator line, message with LF line breaks
+// and escaped "From " lines, and an empty line.
+func toMboxEntry(sender string, date time.Time, raw []byte) []byte {
+	if sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+
+	raw = bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n"))
+	raw = mboxFromLine.ReplaceAll(raw, []byte(">$1"))
+
+	if !bytes.HasSuffix(raw, []byte("\n")) {
+		raw = append(raw, '\n')
+	}
+
+	entry := make([]byte, 0, len(raw)+64) // nolint: gomnd
+	entry = append(entry, fmt.Sprintf("From %s %s\n", sender, date.UTC().Format(time.ANSIC))...)
+	entry = append(entry, raw...)
+
+	return append(entry, '\n')
+}
@@ -0,0 +1,136 @@
+package providers_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/eml"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+var mboxSeparator = regexp.MustCompile(`(?m)^From \S+ .+\n`)
+
+// readMbox splits mboxrd file into messages with unescaped "From " lines.
+func readMbox(t *testing.T, path string) []string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	unescape := regexp.MustCompile(`(?m)^>(>*From )`)
+	entries := make([]string, 0)
+
+	for _, e := range mboxSeparator.Split(string(content), -1)[1:] {
+		e = strings.TrimSuffix(e, "\n")
+		entries = append(entries, unescape.ReplaceAllString(e, "$1"))
+	}
+
+	return entries
+}
+
+func TestMbox_Send(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mbox")
+
+	provider, err := providers.NewMbox(providers.MboxConfig{FilePath: path})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+	msg.MimeType = mime.TextPlain
+	msg.Content = []byte("From the first line\n>From quoted\nnot From line")
+
+	if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	content, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Regexp(t, `^From from@spacetab\.io \w{3} \w{3} [ \d]\d \d{2}:\d{2}:\d{2} \d{4}\n`, string(content))
+	assert.Contains(t, string(content), "\n>From the first line\n>>From quoted\nnot From line\n\n")
+	assert.NotContains(t, string(content), "\r\n")
+
+	entries := readMbox(t, path)
+	if !assert.Len(t, entries, 1) {
+		t.FailNow()
+	}
+
+	parsed, err := eml.Unmarshal([]byte(entries[0]))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// mbox entry always ends with line break
+	assert.Equal(t, string(msg.Content)+"\n", string(parsed.PlainText))
+}
+
+func TestMbox_SendConcurrently(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mbox")
+
+	const senders = 20
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			// every sender has its own provider as separate processes would do
+			provider, err := providers.NewMbox(providers.MboxConfig{FilePath: path})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			msg := newTestMessage(testAttachments)
+			msg.Subject = fmt.Sprintf("message %02d", i)
+
+			assert.NoError(t, provider.Send(context.Background(), &msg))
+		}(i)
+	}
+
+	wg.Wait()
+
+	entries := readMbox(t, path)
+	if !assert.Len(t, entries, senders) {
+		t.FailNow()
+	}
+
+	subjects := make([]string, 0, senders)
+
+	for _, e := range entries {
+		parsed, err := eml.Unmarshal([]byte(e))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		assert.Len(t, parsed.Attachments, len(testAttachments))
+
+		subjects = append(subjects, parsed.Subject)
+	}
+
+	sort.Strings(subjects)
+
+	for i, subject := range subjects {
+		assert.Equal(t, fmt.Sprintf("message %02d", i), subject)
+	}
+}
@@ -0,0 +1,164 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+)
+
+const MailProviderMemory mailing.MailProviderName = "memory"
+
+// Memory provider records copies of sent messages instead of sending them.
+// Failures can be injected for the next calls or for messages to given recipients.
+type Memory struct {
+	mu         sync.Mutex
+	messages   []contracts.MessageInterface
+	calls      int
+	nextErrors []error
+	recipients map[string]error
+	// changed is closed and replaced on every recorded message.
+	changed chan struct{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{recipients: make(map[string]error), changed: make(chan struct{})}
+}
+
+func (m *Memory) Name() mailing.MailProviderName {
+	return MailProviderMemory
+}
+
+func (m *Memory) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := m.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (m *Memory) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+
+	if err := ctx.Err(); err != nil {
+		return contracts.SendResult{Provider: m.Name()}, fmt.Errorf("memory send error: %w", err)
+	}
+
+	if len(m.nextErrors) != 0 {
+		err := m.nextErrors[0]
+		m.nextErrors = m.nextErrors[1:]
+
+		if err != nil {
+			return contracts.SendResult{Provider: m.Name()}, fmt.Errorf("memory send error: %w", err)
+		}
+	}
+
+	for _, email := range recipients(msg) {
+		if err, ok := m.recipients[strings.ToLower(email)]; ok {
+			return contracts.SendResult{Provider: m.Name()}, fmt.Errorf("memory send to %s error: %w", email, err)
+		}
+	}
+
+	m.messages = append(m.messages, msg.Clone())
+
+	close(m.changed)
+	m.changed = make(chan struct{})
+
+	return newSendResult(m.Name(), eml.NewMessageID(msg.GetFrom().GetDomain()), msg), nil
+}
+
+// FailNext makes the next calls fail with errs, one error per call. Nil error lets the call succeed.
+func (m *Memory) FailNext(errs ...error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextErrors = append(m.nextErrors, errs...)
+}
+
+// FailRecipient makes sending of messages to email (in to, cc or bcc) fail with err. Nil error clears
+// the failure, so messages to email are sent again.
+func (m *Memory) FailRecipient(email string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err == nil {
+		delete(m.recipients, strings.ToLower(email))
+
+		return
+	}
+
+	m.recipients[strings.ToLower(email)] = err
+}
+
+// Messages returns copies of recorded messages in send order.
+func (m *Memory) Messages() []contracts.MessageInterface {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]contracts.MessageInterface, 0, len(m.messages))
+	for _, msg := range m.messages {
+		messages = append(messages, msg.Clone())
+	}
+
+	return messages
+}
+
+// SentTo returns recorded messages having email in to, cc or bcc.
+func (m *Memory) SentTo(email string) []contracts.MessageInterface {
+	found := make([]contracts.MessageInterface, 0)
+
+	for _, msg := range m.Messages() {
+		for _, r := range recipients(msg) {
+			if strings.EqualFold(r, email) {
+				found = append(found, msg)
+
+				break
+			}
+		}
+	}
+
+	return found
+}
+
+// Calls returns number of send calls, including failed ones.
+func (m *Memory) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.calls
+}
+
+// Wait waits until at least n messages are recorded and returns them.
+func (m *Memory) Wait(ctx context.Context, n int) ([]contracts.MessageInterface, error) {
+	for {
+		m.mu.Lock()
+		count, changed := len(m.messages), m.changed
+		m.mu.Unlock()
+
+		if count >= n {
+			return m.Messages(), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return m.Messages(), fmt.Errorf("memory wait for %d messages error, got %d: %w", n, count, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Reset removes recorded messages and injected failures.
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = nil
+	m.calls = 0
+	m.nextErrors = nil
+	m.recipients = make(map[string]error)
+}
@@ -0,0 +1,157 @@
+package providers_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory_Send(t *testing.T) {
+	errRejected := errors.New("rejected") // nolint: goerr113
+
+	type expStruct struct {
+		errs  []error
+		sent  []string
+		calls int
+	}
+
+	type testCase struct {
+		name    string
+		prepare func(m *providers.Memory)
+		exp     expStruct
+	}
+
+	tcs := []testCase{
+		{
+			name:    "all sent",
+			prepare: func(_ *providers.Memory) {},
+			exp:     expStruct{errs: []error{nil, nil, nil}, sent: []string{"to@spacetab.io", "cc@spacetab.io", "bcc@spacetab.io"}, calls: 3},
+		},
+		{
+			name:    "failed calls",
+			prepare: func(m *providers.Memory) { m.FailNext(errRejected, nil, errRejected) },
+			exp:     expStruct{errs: []error{errRejected, nil, errRejected}, sent: []string{"cc@spacetab.io"}, calls: 3},
+		},
+		{
+			name:    "failed recipient",
+			prepare: func(m *providers.Memory) { m.FailRecipient("CC@spacetab.io", errRejected) },
+			exp:     expStruct{errs: []error{nil, errRejected, nil}, sent: []string{"to@spacetab.io", "bcc@spacetab.io"}, calls: 3},
+		},
+		{
+			name: "cleared recipient failure",
+			prepare: func(m *providers.Memory) {
+				m.FailRecipient("cc@spacetab.io", errRejected)
+				m.FailRecipient("CC@spacetab.io", nil)
+			},
+			exp: expStruct{errs: []error{nil, nil, nil}, sent: []string{"to@spacetab.io", "cc@spacetab.io", "bcc@spacetab.io"}, calls: 3},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := providers.NewMemory()
+			tc.prepare(m)
+
+			msgs := []contracts.Message{newTestMessage(nil), newTestMessage(nil), newTestMessage(nil)}
+			msgs[0].To = mailing.MailAddressList{{Email: "to@spacetab.io"}}
+			msgs[1].To = nil
+			msgs[1].Cc = mailing.MailAddressList{{Email: "cc@spacetab.io"}}
+			msgs[2].To = nil
+			msgs[2].Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+
+			for i := range msgs {
+				err := m.Send(context.Background(), &msgs[i])
+				if tc.exp.errs[i] != nil {
+					assert.ErrorIs(t, err, tc.exp.errs[i])
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+
+			sent := make([]string, 0)
+			for _, email := range tc.exp.sent {
+				if assert.Len(t, m.SentTo(email), 1) {
+					sent = append(sent, email)
+				}
+			}
+
+			assert.Equal(t, tc.exp.sent, sent)
+			assert.Len(t, m.Messages(), len(tc.exp.sent))
+			assert.Equal(t, tc.exp.calls, m.Calls())
+		})
+	}
+}
+
+func TestMemory_SendCopiesMessage(t *testing.T) {
+	t.Parallel()
+
+	m := providers.NewMemory()
+	msg := newTestMessage(testAttachments.Clone())
+
+	if !assert.NoError(t, m.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	msg.Subject = "changed"
+	msg.Attachments[0].Content[0] = 'P'
+
+	sent := m.Messages()
+	if !assert.Len(t, sent, 1) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "Test email", sent[0].GetSubject())
+	assert.Equal(t, []byte("png"), sent[0].GetAttachments().GetList()[0].GetContent())
+}
+
+func TestMemory_Wait(t *testing.T) {
+	t.Parallel()
+
+	m := providers.NewMemory()
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			time.Sleep(10 * time.Millisecond)
+
+			msg := newTestMessage(nil)
+			assert.NoError(t, m.Send(context.Background(), &msg))
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sent, err := m.Wait(ctx, 3)
+	assert.NoError(t, err)
+	assert.Len(t, sent, 3)
+
+	wg.Wait()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	sent, err = m.Wait(ctx, 4)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, sent, 3)
+
+	m.Reset()
+	assert.Empty(t, m.Messages())
+	assert.Equal(t, 0, m.Calls())
+}
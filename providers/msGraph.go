@@ -0,0 +1,403 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const (
+	// msGraphRequestSizeLimit is a size limit of graph api request, attachments are base64 encoded within it.
+	msGraphRequestSizeLimit = 4 << 20
+	// msGraphUploadChunkSize is upload session chunk size, which must be a multiple of 320 KiB.
+	msGraphUploadChunkSize = 10 * 320 << 10
+
+	msGraphFileAttachment = "#microsoft.graph.fileAttachment"
+
+	// msGraphDraftDeleteTimeout bounds draft deletion, which runs when send context may be already done.
+	msGraphDraftDeleteTimeout = 10 * time.Second
+)
+
+// MSGraph sends messages with Microsoft Graph sendMail api from Sender mailbox, so it works where SMTP AUTH
+// is disabled. Messages exceeding 4 MB request limit with base64 encoded attachments are created as a draft,
+// attachments not fitting into it are added one by one, the ones exceeding the limit themselves (about 3 MB
+// and more) are uploaded with upload sessions, and the draft is sent then; such messages are always saved
+// to sent items.
+type MSGraph struct {
+	client      *http.Client
+	endpoint    string
+	tokens      *msGraphTokenSource
+	providerCfg MSGraphConfigInterface
+}
+
+func NewMSGraph(providerCfg mailing.MailProviderConfigInterface) (MSGraph, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return MSGraph{}, fmt.Errorf("ms graph provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(MSGraphConfigInterface)
+	if !ok {
+		return MSGraph{}, fmt.Errorf("ms graph provider config error: %T is not MSGraphConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	client := &http.Client{}
+
+	return MSGraph{
+		client:      client,
+		endpoint:    strings.TrimSuffix(cfg.GetHostPort().String(), "/"),
+		tokens:      newMSGraphTokenSource(client, cfg),
+		providerCfg: cfg,
+	}, nil
+}
+
+func (o MSGraph) Name() mailing.MailProviderName {
+	return "msGraphAPI"
+}
+
+func (o MSGraph) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+type msGraphEmailAddress struct {
+	Address string `json:"address"`
+	Name    string `json:"name,omitempty"`
+}
+
+type msGraphRecipient struct {
+	EmailAddress msGraphEmailAddress `json:"emailAddress"`
+}
+
+type msGraphBody struct {
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+}
+
+type msGraphAttachment struct {
+	ODataType    string `json:"@odata.type"`
+	Name         string `json:"name"`
+	ContentType  string `json:"contentType"`
+	ContentBytes []byte `json:"contentBytes"`
+	IsInline     bool   `json:"isInline,omitempty"`
+	ContentID    string `json:"contentId,omitempty"`
+}
+
+type msGraphMessage struct {
+	Subject           string              `json:"subject"`
+	Body              msGraphBody         `json:"body"`
+	From              *msGraphRecipient   `json:"from,omitempty"`
+	ToRecipients      []msGraphRecipient  `json:"toRecipients"`
+	CcRecipients      []msGraphRecipient  `json:"ccRecipients,omitempty"`
+	BccRecipients     []msGraphRecipient  `json:"bccRecipients,omitempty"`
+	ReplyTo           []msGraphRecipient  `json:"replyTo,omitempty"`
+	InternetMessageID string              `json:"internetMessageId"`
+	Attachments       []msGraphAttachment `json:"attachments,omitempty"`
+}
+
+type msGraphSendMailRequest struct {
+	Message         msGraphMessage `json:"message"`
+	SaveToSentItems bool           `json:"saveToSentItems"`
+}
+
+type msGraphAttachmentItem struct {
+	AttachmentType string `json:"attachmentType"`
+	Name           string `json:"name"`
+	Size           int    `json:"size"`
+	ContentType    string `json:"contentType"`
+	IsInline       bool   `json:"isInline,omitempty"`
+	ContentID      string `json:"contentId,omitempty"`
+}
+
+type msGraphUploadSessionRequest struct {
+	AttachmentItem msGraphAttachmentItem `json:"AttachmentItem"`
+}
+
+type msGraphUploadSession struct {
+	UploadURL string `json:"uploadUrl"`
+}
+
+type msGraphDraft struct {
+	ID string `json:"id"`
+}
+
+type msGraphErrorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (o MSGraph) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	sender := o.providerCfg.GetSender()
+	if sender == "" {
+		sender = msg.GetFrom().GetEmail()
+	}
+
+	if sender == "" {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("ms graph sender mailbox error: %w", mailErrors.Permanent(0, mailErrors.ErrEmptyAddress))
+	}
+
+	messageID := eml.NewMessageID(msg.GetFrom().GetDomain())
+	gm, separate := toMSGraphMessage(msg, messageID)
+	userURL := o.endpoint + "/users/" + url.PathEscape(sender)
+
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	var err error
+	if len(separate) == 0 {
+		err = o.call(ctx, http.MethodPost, userURL+"/sendMail", msGraphSendMailRequest{
+			Message:         gm,
+			SaveToSentItems: o.providerCfg.GetSaveToSentItems(),
+		}, nil)
+	} else {
+		err = o.sendDraft(ctx, userURL, gm, separate)
+	}
+
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("ms graph send error: %w", err)
+	}
+
+	return newSendResult(o.Name(), messageID, msg), nil
+}
+
+// sendDraft creates draft message, adds separate attachments into it and sends it. Draft is deleted on failure,
+// including send timeout or cancellation.
+func (o MSGraph) sendDraft(ctx context.Context, userURL string, gm msGraphMessage, separate []contracts.MessageAttachmentInterface) (err error) {
+	var draft msGraphDraft
+	if err := o.call(ctx, http.MethodPost, userURL+"/messages", gm, &draft); err != nil {
+		return fmt.Errorf("draft create error: %w", err)
+	}
+
+	draftURL := userURL + "/messages/" + url.PathEscape(draft.ID)
+
+	defer func() {
+		if err != nil {
+			deleteCtx, cancel := context.WithTimeout(context.Background(), msGraphDraftDeleteTimeout)
+			defer cancel()
+
+			_ = o.call(deleteCtx, http.MethodDelete, draftURL, nil, nil)
+		}
+	}()
+
+	for _, att := range separate {
+		if ga := toMSGraphAttachment(att); msGraphAttachmentSize(ga) <= msGraphRequestSizeLimit {
+			if err := o.call(ctx, http.MethodPost, draftURL+"/attachments", ga, nil); err != nil {
+				return fmt.Errorf("attachment %s add error: %w", contracts.AttachmentFileName(att), err)
+			}
+
+			continue
+		}
+
+		if err := o.uploadAttachment(ctx, draftURL, att); err != nil {
+			return fmt.Errorf("attachment %s upload error: %w", contracts.AttachmentFileName(att), err)
+		}
+	}
+
+	if err := o.call(ctx, http.MethodPost, draftURL+"/send", nil, nil); err != nil {
+		return fmt.Errorf("draft send error: %w", err)
+	}
+
+	return nil
+}
+
+func (o MSGraph) uploadAttachment(ctx context.Context, draftURL string, att contracts.MessageAttachmentInterface) error {
+	content := att.GetContent()
+	item := msGraphAttachmentItem{
+		AttachmentType: "file",
+		Name:           contracts.AttachmentFileName(att),
+		Size:           len(content),
+		ContentType:    att.GetMimeType(),
+	}
+
+	if att.GetAttachMethod() == contracts.AttachMethodInline {
+		item.IsInline, item.ContentID = true, contracts.AttachmentFileName(att)
+	}
+
+	var session msGraphUploadSession
+	if err := o.call(ctx, http.MethodPost, draftURL+"/attachments/createUploadSession", msGraphUploadSessionRequest{AttachmentItem: item}, &session); err != nil {
+		return fmt.Errorf("upload session create error: %w", err)
+	}
+
+	for start := 0; start < len(content); start += msGraphUploadChunkSize {
+		end := start + msGraphUploadChunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, session.UploadURL, bytes.NewReader(content[start:end]))
+		if err != nil {
+			return mailErrors.Permanent(0, fmt.Errorf("upload request build error: %w", err))
+		}
+
+		// upload url is pre-authenticated, so request must not have Authorization header
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, len(content)))
+
+		resp, body, err := doRequest(o.client, req)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return msGraphError(resp.StatusCode, body)
+		}
+	}
+
+	return nil
+}
+
+// call sends authorized request to graph api and decodes response into out if it is set.
+// Requests with in have json encoded in as body, others have no body.
+func (o MSGraph) call(ctx context.Context, method string, url string, in interface{}, out interface{}) error {
+	token, err := o.tokens.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("access token error: %w", err)
+	}
+
+	var req *http.Request
+
+	if in != nil {
+		if req, _, err = newJSONRequest(ctx, url, in); err != nil {
+			return err
+		}
+
+		req.Method = method
+	} else if req, err = http.NewRequestWithContext(ctx, method, url, http.NoBody); err != nil {
+		return mailErrors.Permanent(0, fmt.Errorf("request build error: %w", err))
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, body, err := doRequest(o.client, req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		o.tokens.invalidate(token)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return msGraphError(resp.StatusCode, body)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return mailErrors.Permanent(0, fmt.Errorf("response decode error: %w", err))
+		}
+	}
+
+	return nil
+}
+
+func msGraphError(status int, body []byte) error {
+	var er msGraphErrorResponse
+
+	_ = json.Unmarshal(body, &er)
+
+	return mailErrors.FromHTTPStatus(status, fmt.Errorf("%d %s: %s", status, er.Error.Code, er.Error.Message)) // nolint: goerr113
+}
+
+// toMSGraphMessage returns graph message and its attachments which don't fit into sendMail request size limit,
+// so are added to draft separately. Graph message has a single body, so html one is used for alternative messages.
+func toMSGraphMessage(msg contracts.MessageInterface, messageID string) (msGraphMessage, []contracts.MessageAttachmentInterface) {
+	gm := msGraphMessage{
+		Subject:           msg.GetSubject(),
+		ToRecipients:      toMSGraphRecipients(msg.GetTo()),
+		CcRecipients:      toMSGraphRecipients(msg.GetCc()),
+		BccRecipients:     toMSGraphRecipients(msg.GetBcc()),
+		InternetMessageID: "<" + messageID + ">",
+	}
+
+	if !msg.GetFrom().IsEmpty() {
+		gm.From = &msGraphRecipient{EmailAddress: msGraphEmailAddress{Address: msg.GetFrom().GetEmail(), Name: msg.GetFrom().GetName()}}
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		gm.ReplyTo = []msGraphRecipient{{EmailAddress: msGraphEmailAddress{Address: msg.GetReplyTo().GetEmail(), Name: msg.GetReplyTo().GetName()}}}
+	}
+
+	switch {
+	case msg.IsAlternative():
+		gm.Body = msGraphBody{ContentType: "HTML", Content: string(msg.GetHTML())}
+	case msg.GetMimeType() == mime.TextHTML:
+		gm.Body = msGraphBody{ContentType: "HTML", Content: string(msg.GetBody())}
+	default:
+		gm.Body = msGraphBody{ContentType: "Text", Content: string(msg.GetBody())}
+	}
+
+	separate := make([]contracts.MessageAttachmentInterface, 0)
+	size := msGraphJSONSize(msGraphSendMailRequest{Message: gm}) + len(`,"attachments":[]`)
+
+	for _, att := range msg.GetAttachments().GetList() {
+		ga := toMSGraphAttachment(att)
+
+		if attSize := msGraphAttachmentSize(ga) + len(","); size+attSize <= msGraphRequestSizeLimit {
+			size += attSize
+			gm.Attachments = append(gm.Attachments, ga)
+
+			continue
+		}
+
+		separate = append(separate, att)
+	}
+
+	return gm, separate
+}
+
+func toMSGraphAttachment(att contracts.MessageAttachmentInterface) msGraphAttachment {
+	ga := msGraphAttachment{
+		ODataType:    msGraphFileAttachment,
+		Name:         contracts.AttachmentFileName(att),
+		ContentType:  att.GetMimeType(),
+		ContentBytes: att.GetContent(),
+	}
+
+	if att.GetAttachMethod() == contracts.AttachMethodInline {
+		ga.IsInline, ga.ContentID = true, contracts.AttachmentFileName(att)
+	}
+
+	return ga
+}
+
+// msGraphAttachmentSize returns size of json encoded attachment without encoding its content.
+func msGraphAttachmentSize(ga msGraphAttachment) int {
+	content := ga.ContentBytes
+	ga.ContentBytes = nil
+
+	return msGraphJSONSize(ga) - len("null") + len(`""`) + base64.StdEncoding.EncodedLen(len(content))
+}
+
+func msGraphJSONSize(v interface{}) int {
+	b, _ := json.Marshal(v)
+
+	return len(b)
+}
+
+func toMSGraphRecipients(list mailing.MailAddressListInterface) []msGraphRecipient {
+	if list == nil || list.IsEmpty() {
+		return nil
+	}
+
+	rs := make([]msGraphRecipient, 0, len(list.GetList()))
+	for _, addr := range list.GetList() {
+		rs = append(rs, msGraphRecipient{EmailAddress: msGraphEmailAddress{Address: addr.GetEmail(), Name: addr.GetName()}})
+	}
+
+	return rs
+}
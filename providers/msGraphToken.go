@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+// msGraphTokenExpiryDelta is a time before token expiry when it is already refreshed, so it does not expire in flight.
+const msGraphTokenExpiryDelta = time.Minute
+
+// msGraphTokenSource acquires application access tokens with OAuth2 client credentials grant
+// and caches them until expiry. It is shared by provider copies.
+type msGraphTokenSource struct {
+	client       *http.Client
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scope        string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+type msGraphTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func newMSGraphTokenSource(client *http.Client, cfg MSGraphConfigInterface) *msGraphTokenSource {
+	return &msGraphTokenSource{
+		client:       client,
+		tokenURL:     strings.TrimSuffix(cfg.GetAuthority(), "/") + "/" + url.PathEscape(cfg.GetTenantID()) + "/oauth2/v2.0/token",
+		clientID:     cfg.GetUsername(),
+		clientSecret: cfg.GetPassword(),
+		scope:        cfg.GetScope(),
+	}
+}
+
+// Token returns cached access token or acquires new one if it is missing or about to expire.
+func (s *msGraphTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(msGraphTokenExpiryDelta).Before(s.expires) {
+		return s.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+		"scope":         {s.scope},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", mailErrors.Permanent(0, fmt.Errorf("token request build error: %w", err))
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, body, err := doRequest(s.client, req)
+	if err != nil {
+		return "", fmt.Errorf("token request error: %w", err)
+	}
+
+	var tr msGraphTokenResponse
+
+	_ = json.Unmarshal(body, &tr)
+
+	if resp.StatusCode != http.StatusOK || tr.AccessToken == "" {
+		return "", mailErrors.FromHTTPStatus(
+			resp.StatusCode,
+			fmt.Errorf("%d token error %s: %s", resp.StatusCode, tr.Error, tr.ErrorDescription), // nolint: goerr113
+		)
+	}
+
+	s.token = tr.AccessToken
+	s.expires = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+
+	return s.token, nil
+}
+
+// invalidate drops token rejected by api, unless it is already replaced with new one.
+func (s *msGraphTokenSource) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
@@ -0,0 +1,548 @@
+package providers_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+// graphStandIn impersonates Microsoft identity platform and Graph api: it issues tokens, accepts
+// sendMail requests, drafts and upload sessions and records every received request.
+type graphStandIn struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests []recordedRequest
+	tokens   int
+	uploaded []byte
+	// status replaces graph api (not token endpoint) response status if it is set.
+	status   int
+	response string
+	// delay delays responses to requests with delayPath path suffix.
+	delayPath string
+	delay     time.Duration
+}
+
+func newGraphStandIn(t *testing.T) *graphStandIn {
+	t.Helper()
+
+	s := &graphStandIn{}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		s.mu.Lock()
+		delay := s.delay
+		if !strings.HasSuffix(r.URL.Path, s.delayPath) {
+			delay = 0
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, recordedRequest{method: r.Method, path: r.URL.Path, header: r.Header.Clone(), body: body})
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/oauth2/v2.0/token"):
+			s.tokens++
+			_, _ = fmt.Fprintf(w, `{"token_type":"Bearer","expires_in":3599,"access_token":"token-%d"}`, s.tokens)
+		case s.status != 0:
+			w.WriteHeader(s.status)
+			_, _ = io.WriteString(w, s.response)
+		case strings.HasSuffix(r.URL.Path, "/sendMail"), strings.HasSuffix(r.URL.Path, "/send"):
+			w.WriteHeader(http.StatusAccepted)
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"draft-1"}`)
+		case strings.HasSuffix(r.URL.Path, "/attachments"):
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"attachment-1"}`)
+		case strings.HasSuffix(r.URL.Path, "/createUploadSession"):
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"uploadUrl":"%s/upload/1"}`, s.server.URL)
+		case r.URL.Path == "/upload/1":
+			s.uploaded = append(s.uploaded, body...)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *graphStandIn) config() providers.MSGraphConfig {
+	return providers.MSGraphConfig{
+		TenantID:     "tenant",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Authority:    s.server.URL,
+		Endpoint:     s.server.URL + "/v1.0",
+	}
+}
+
+func (s *graphStandIn) setResponse(status int, response string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status, s.response = status, response
+}
+
+func (s *graphStandIn) setDelay(pathSuffix string, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.delayPath, s.delay = pathSuffix, delay
+}
+
+func (s *graphStandIn) received() []recordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func TestMSGraph_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		cfg  func(cfg providers.MSGraphConfig) providers.MSGraphConfig
+		in   contracts.Message
+		path string
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			cfg:  func(cfg providers.MSGraphConfig) providers.MSGraphConfig { return cfg },
+			in:   newTestMessage(nil),
+			path: "/v1.0/users/from@spacetab.io/sendMail",
+			exp: `{"message":{
+				"subject":"Test email","body":{"contentType":"HTML","content":"<p>test email content</p>"},
+				"from":{"emailAddress":{"address":"from@spacetab.io","name":"From"}},
+				"toRecipients":[{"emailAddress":{"address":"to@spacetab.io","name":"To"}}],
+				"internetMessageId":"<%s>"
+			},"saveToSentItems":false}`,
+		},
+		{
+			name: "inline and file attachments",
+			cfg:  func(cfg providers.MSGraphConfig) providers.MSGraphConfig { return cfg },
+			in:   newTestMessage(testAttachments),
+			path: "/v1.0/users/from@spacetab.io/sendMail",
+			exp: `{"message":{
+				"subject":"Test email","body":{"contentType":"HTML","content":"<p>test email content</p>"},
+				"from":{"emailAddress":{"address":"from@spacetab.io","name":"From"}},
+				"toRecipients":[{"emailAddress":{"address":"to@spacetab.io","name":"To"}}],
+				"internetMessageId":"<%s>",
+				"attachments":[
+					{"@odata.type":"#microsoft.graph.fileAttachment","name":"logo.png","contentType":"image/png","contentBytes":"cG5n","isInline":true,"contentId":"logo.png"},
+					{"@odata.type":"#microsoft.graph.fileAttachment","name":"report.pdf","contentType":"application/pdf","contentBytes":"cGRm"}
+				]
+			},"saveToSentItems":false}`,
+		},
+		{
+			name: "shared mailbox sender with copies",
+			cfg: func(cfg providers.MSGraphConfig) providers.MSGraphConfig {
+				cfg.Sender = "noreply@spacetab.io"
+				cfg.SaveToSentItems = true
+
+				return cfg
+			},
+			in: func() contracts.Message {
+				msg := newAlternativeTestMessage()
+				msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io"}}
+				msg.Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+				msg.ReplyTo = mailing.MailAddress{Email: "reply@spacetab.io"}
+
+				return msg
+			}(),
+			path: "/v1.0/users/noreply@spacetab.io/sendMail",
+			exp: `{"message":{
+				"subject":"Test email","body":{"contentType":"HTML","content":"<p>test email content</p>"},
+				"from":{"emailAddress":{"address":"from@spacetab.io","name":"From"}},
+				"toRecipients":[{"emailAddress":{"address":"to@spacetab.io","name":"To"}}],
+				"ccRecipients":[{"emailAddress":{"address":"cc@spacetab.io"}}],
+				"bccRecipients":[{"emailAddress":{"address":"bcc@spacetab.io"}}],
+				"replyTo":[{"emailAddress":{"address":"reply@spacetab.io"}}],
+				"internetMessageId":"<%s>"
+			},"saveToSentItems":true}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newGraphStandIn(t)
+
+			provider, err := providers.NewMSGraph(tc.cfg(api.config()))
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in
+
+			res, err := provider.SendWithResult(context.Background(), &msg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 2) {
+				t.FailNow()
+			}
+
+			token, send := requests[0], requests[1]
+
+			assert.Equal(t, "/tenant/oauth2/v2.0/token", token.path)
+
+			form, err := url.ParseQuery(string(token.body))
+			if assert.NoError(t, err) {
+				assert.Equal(t, url.Values{
+					"grant_type":    {"client_credentials"},
+					"client_id":     {"client"},
+					"client_secret": {"secret"},
+					"scope":         {"https://graph.microsoft.com/.default"},
+				}, form)
+			}
+
+			assert.Equal(t, http.MethodPost, send.method)
+			assert.Equal(t, tc.path, send.path)
+			assert.Equal(t, "Bearer token-1", send.header.Get("Authorization"))
+			assert.JSONEq(t, fmt.Sprintf(tc.exp, res.MessageID), string(send.body))
+
+			assert.Equal(t, provider.Name(), res.Provider)
+			assert.NotEmpty(t, res.Accepted)
+		})
+	}
+}
+
+func TestMSGraph_SendTokenCache(t *testing.T) {
+	t.Parallel()
+
+	api := newGraphStandIn(t)
+
+	provider, err := providers.NewMSGraph(api.config())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	for i := 0; i < 3; i++ {
+		if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+			t.FailNow()
+		}
+	}
+
+	// rejected token is dropped, so the next send acquires new one
+	api.setResponse(http.StatusUnauthorized, `{"error":{"code":"InvalidAuthenticationToken","message":"Access token has expired."}}`)
+	assert.ErrorIs(t, provider.Send(context.Background(), &msg), mailErrors.ErrPermanent)
+
+	api.setResponse(0, "")
+	assert.NoError(t, provider.Send(context.Background(), &msg))
+
+	tokens := make([]string, 0)
+	for _, r := range api.received() {
+		if strings.HasSuffix(r.path, "/sendMail") {
+			tokens = append(tokens, r.header.Get("Authorization"))
+		}
+	}
+
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-1", "Bearer token-1", "Bearer token-1", "Bearer token-2"}, tokens)
+}
+
+func TestMSGraph_SendLargeAttachment(t *testing.T) {
+	t.Parallel()
+
+	api := newGraphStandIn(t)
+
+	provider, err := providers.NewMSGraph(api.config())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	large := bytes.Repeat([]byte("0123456789abcdef"), 256<<10) // 4 MiB
+
+	msg := newTestMessage(append(testAttachments.Clone(), contracts.Attachment{
+		MimeType:     "application/zip",
+		AttachMethod: contracts.AttachMethodFile,
+		Filename:     "archive.zip",
+		Content:      large,
+	}))
+
+	if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	requests := api.received()
+	paths := make([]string, 0, len(requests))
+
+	for _, r := range requests {
+		paths = append(paths, r.method+" "+r.path)
+	}
+
+	assert.Equal(t, []string{
+		"POST /tenant/oauth2/v2.0/token",
+		"POST /v1.0/users/from@spacetab.io/messages",
+		"POST /v1.0/users/from@spacetab.io/messages/draft-1/attachments/createUploadSession",
+		"PUT /upload/1",
+		"PUT /upload/1",
+		"POST /v1.0/users/from@spacetab.io/messages/draft-1/send",
+	}, paths)
+
+	// small attachments are sent within draft
+	assert.Contains(t, string(requests[1].body), `"name":"report.pdf"`)
+	assert.NotContains(t, string(requests[1].body), "archive.zip")
+	assert.JSONEq(t, `{"AttachmentItem":{"attachmentType":"file","name":"archive.zip","size":4194304,"contentType":"application/zip"}}`, string(requests[2].body))
+
+	assert.Equal(t, "bytes 0-3276799/4194304", requests[3].header.Get("Content-Range"))
+	assert.Equal(t, "bytes 3276800-4194303/4194304", requests[4].header.Get("Content-Range"))
+	assert.Empty(t, requests[3].header.Get("Authorization"))
+	assert.Equal(t, large, api.uploaded)
+	assert.Empty(t, requests[5].body)
+}
+
+func TestMSGraph_SendAttachmentsSizeLimit(t *testing.T) {
+	type testCase struct {
+		name        string
+		attachments []int
+		expPaths    []string
+		// expInline are names of attachments sent within sendMail request or draft
+		expInline []string
+	}
+
+	const (
+		sendMail = "POST /v1.0/users/from@spacetab.io/sendMail"
+		draft    = "POST /v1.0/users/from@spacetab.io/messages"
+		add      = "POST /v1.0/users/from@spacetab.io/messages/draft-1/attachments"
+		upload   = add + "/createUploadSession"
+		send     = "POST /v1.0/users/from@spacetab.io/messages/draft-1/send"
+	)
+
+	tcs := []testCase{
+		{
+			name:        "medium attachments fitting request",
+			attachments: []int{1 << 20, 1 << 20},
+			expPaths:    []string{sendMail},
+			expInline:   []string{"file-0.bin", "file-1.bin"},
+		},
+		{
+			// 3 MB minus a byte is 4 MB after base64 encoding
+			name:        "attachment just under 3 MB",
+			attachments: []int{3<<20 - 1},
+			expPaths:    []string{draft, upload, "PUT /upload/1", send},
+		},
+		{
+			name:        "several medium attachments",
+			attachments: []int{1 << 20, 1 << 20, 1 << 20},
+			expPaths:    []string{draft, add, send},
+			expInline:   []string{"file-0.bin", "file-1.bin"},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newGraphStandIn(t)
+
+			provider, err := providers.NewMSGraph(api.config())
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			attachments := make(contracts.MessageAttachmentList, 0, len(tc.attachments))
+			for i, size := range tc.attachments {
+				attachments = append(attachments, contracts.Attachment{
+					MimeType:     "application/octet-stream",
+					AttachMethod: contracts.AttachMethodFile,
+					Filename:     fmt.Sprintf("file-%d.bin", i),
+					Content:      bytes.Repeat([]byte{'0'}, size),
+				})
+			}
+
+			msg := newTestMessage(attachments)
+
+			if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+				t.FailNow()
+			}
+
+			requests := api.received()[1:]
+			paths := make([]string, 0, len(requests))
+
+			for _, r := range requests {
+				paths = append(paths, r.method+" "+r.path)
+				assert.LessOrEqual(t, len(r.body), 4<<20)
+			}
+
+			assert.Equal(t, tc.expPaths, paths)
+
+			inline := make([]string, 0, len(tc.expInline))
+			for _, att := range attachments {
+				if strings.Contains(string(requests[0].body), `"name":"`+att.Filename+`"`) {
+					inline = append(inline, att.Filename)
+				}
+			}
+
+			assert.ElementsMatch(t, tc.expInline, inline)
+		})
+	}
+}
+
+func TestMSGraph_SendDraftTimeout(t *testing.T) {
+	t.Parallel()
+
+	api := newGraphStandIn(t)
+	api.setDelay("/send", time.Second)
+
+	cfg := api.config()
+	cfg.SendTimeout = 100 * time.Millisecond
+
+	provider, err := providers.NewMSGraph(cfg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(contracts.MessageAttachmentList{{
+		MimeType:     "application/zip",
+		AttachMethod: contracts.AttachMethodFile,
+		Filename:     "archive.zip",
+		Content:      bytes.Repeat([]byte{'0'}, 4<<20),
+	}})
+
+	assert.ErrorIs(t, provider.Send(context.Background(), &msg), context.DeadlineExceeded)
+
+	paths := make([]string, 0)
+	for _, r := range api.received() {
+		paths = append(paths, r.method+" "+r.path)
+	}
+
+	// draft is deleted though send context is done
+	assert.Contains(t, paths, "DELETE /v1.0/users/from@spacetab.io/messages/draft-1")
+}
+
+func TestMSGraph_SendErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		status   int
+		response string
+		exp      error
+	}
+
+	tcs := []testCase{
+		{name: "mailbox not found", status: http.StatusNotFound, response: `{"error":{"code":"ErrorInvalidUser","message":"The requested user is invalid."}}`, exp: mailErrors.ErrPermanent},
+		{name: "forbidden", status: http.StatusForbidden, response: `{"error":{"code":"ErrorAccessDenied","message":"Access is denied."}}`, exp: mailErrors.ErrPermanent},
+		{name: "throttled", status: http.StatusTooManyRequests, response: `{"error":{"code":"ApplicationThrottled","message":"Too many requests."}}`, exp: mailErrors.ErrTemporary},
+		{name: "service unavailable", status: http.StatusServiceUnavailable, response: ``, exp: mailErrors.ErrTemporary},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newGraphStandIn(t)
+			api.setResponse(tc.status, tc.response)
+
+			provider, err := providers.NewMSGraph(api.config())
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestMSGraph_SendTokenError(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusUnauthorized, "application/json", `{"error":"invalid_client","error_description":"AADSTS7000215: Invalid client secret provided."}`)
+
+	provider, err := providers.NewMSGraph(providers.MSGraphConfig{
+		TenantID:     "tenant",
+		ClientID:     "client",
+		ClientSecret: "wrong",
+		Authority:    api.server.URL,
+		Endpoint:     api.server.URL,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	err = provider.Send(context.Background(), &msg)
+	assert.ErrorIs(t, err, mailErrors.ErrPermanent)
+	assert.Contains(t, err.Error(), "invalid_client")
+	assert.Len(t, api.received(), 1)
+}
+
+func TestNewMSGraph(t *testing.T) {
+	type testCase struct {
+		name  string
+		in    providers.MSGraphConfig
+		isErr bool
+	}
+
+	tcs := []testCase{
+		{name: "valid", in: providers.MSGraphConfig{TenantID: "tenant", ClientID: "client", ClientSecret: "secret"}},
+		{name: "no tenant", in: providers.MSGraphConfig{ClientID: "client", ClientSecret: "secret"}, isErr: true},
+		{name: "no secret", in: providers.MSGraphConfig{TenantID: "tenant", ClientID: "client"}, isErr: true},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := providers.NewMSGraph(tc.in)
+			if tc.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const (
+	postmarkSendPath        = "/email"
+	postmarkBatchPath       = "/email/batch"
+	postmarkMaxBatchSize    = 500
+	postmarkTokenHeader     = "X-Postmark-Server-Token" // nolint: gosec
+	postmarkCodeMaintenance = 100
+)
+
+// Postmark sends messages with Postmark api.
+type Postmark struct {
+	client      *http.Client
+	endpoint    string
+	providerCfg PostmarkConfigInterface
+}
+
+func NewPostmark(providerCfg mailing.MailProviderConfigInterface) (Postmark, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return Postmark{}, fmt.Errorf("postmark provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(PostmarkConfigInterface)
+	if !ok {
+		return Postmark{}, fmt.Errorf("postmark provider config error: %T is not PostmarkConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	return Postmark{
+		client:      &http.Client{},
+		endpoint:    strings.TrimSuffix(cfg.GetHostPort().String(), "/"),
+		providerCfg: cfg,
+	}, nil
+}
+
+func (o Postmark) Name() mailing.MailProviderName {
+	return "postmarkAPI"
+}
+
+func (o Postmark) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+type postmarkAttachment struct {
+	Name        string `json:"Name"`
+	Content     []byte `json:"Content"`
+	ContentType string `json:"ContentType"`
+	ContentID   string `json:"ContentID,omitempty"`
+}
+
+type postmarkMessage struct {
+	From          string               `json:"From"`
+	To            string               `json:"To"`
+	Cc            string               `json:"Cc,omitempty"`
+	Bcc           string               `json:"Bcc,omitempty"`
+	ReplyTo       string               `json:"ReplyTo,omitempty"`
+	Subject       string               `json:"Subject"`
+	Tag           string               `json:"Tag,omitempty"`
+	HTMLBody      string               `json:"HtmlBody,omitempty"`
+	TextBody      string               `json:"TextBody,omitempty"`
+	TrackOpens    *bool                `json:"TrackOpens,omitempty"`
+	TrackLinks    string               `json:"TrackLinks,omitempty"`
+	Metadata      map[string]string    `json:"Metadata,omitempty"`
+	Attachments   []postmarkAttachment `json:"Attachments,omitempty"`
+	MessageStream string               `json:"MessageStream"`
+}
+
+type postmarkResponse struct {
+	To        string `json:"To"`
+	MessageID string `json:"MessageID"`
+	ErrorCode int    `json:"ErrorCode"`
+	Message   string `json:"Message"`
+}
+
+func (o Postmark) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	var resp postmarkResponse
+	if err := o.post(ctx, postmarkSendPath, o.toPostmarkMessage(ctx, msg), &resp); err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("postmark send message error: %w", err)
+	}
+
+	return newSendResult(o.Name(), resp.MessageID, msg), nil
+}
+
+// SendBatch sends messages with batch endpoint, up to 500 messages per request. Messages Postmark
+// didn't accept get all their recipients rejected with Postmark error as a reason in their results.
+// Error is returned if request fails, results of already sent batches are returned with it.
+func (o Postmark) SendBatch(ctx context.Context, msgs []contracts.MessageInterface) ([]contracts.SendResult, error) {
+	results := make([]contracts.SendResult, 0, len(msgs))
+
+	for start := 0; start < len(msgs); start += postmarkMaxBatchSize {
+		end := start + postmarkMaxBatchSize
+		if end > len(msgs) {
+			end = len(msgs)
+		}
+
+		batch := make([]postmarkMessage, 0, end-start)
+		for _, msg := range msgs[start:end] {
+			batch = append(batch, o.toPostmarkMessage(ctx, msg))
+		}
+
+		var resp []postmarkResponse
+		if err := o.post(ctx, postmarkBatchPath, batch, &resp); err != nil {
+			return results, fmt.Errorf("postmark send batch error: %w", err)
+		}
+
+		for i, msg := range msgs[start:end] {
+			switch {
+			case i >= len(resp):
+				results = append(results, newRejectedResult(o.Name(), "missing in batch response", msg))
+			case resp[i].ErrorCode != 0:
+				results = append(results, newRejectedResult(o.Name(), fmt.Sprintf("%d %s", resp[i].ErrorCode, resp[i].Message), msg))
+			default:
+				results = append(results, newSendResult(o.Name(), resp[i].MessageID, msg))
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// post sends request to api path and decodes successful response into out.
+func (o Postmark) post(ctx context.Context, path string, in interface{}, out interface{}) error {
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	req, _, err := newJSONRequest(ctx, o.endpoint+path, in)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(postmarkTokenHeader, o.providerCfg.GetPassword())
+
+	resp, body, err := doRequest(o.client, req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return classifyPostmarkError(resp.StatusCode, body)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("response decode error: %w", err)
+	}
+
+	return nil
+}
+
+func (o Postmark) toPostmarkMessage(ctx context.Context, msg contracts.MessageInterface) postmarkMessage {
+	pm := postmarkMessage{
+		From:          msg.GetFrom().String(),
+		To:            strings.Join(addressList(msg.GetTo()), ","),
+		Cc:            strings.Join(addressList(msg.GetCc()), ","),
+		Bcc:           strings.Join(addressList(msg.GetBcc()), ","),
+		Subject:       msg.GetSubject(),
+		Tag:           o.providerCfg.GetTag(),
+		TrackOpens:    o.providerCfg.GetTrackOpens(),
+		TrackLinks:    o.providerCfg.GetTrackLinks(),
+		MessageStream: o.providerCfg.GetMessageStream(),
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		pm.ReplyTo = msg.GetReplyTo().String()
+	}
+
+	switch {
+	case msg.IsAlternative():
+		pm.HTMLBody, pm.TextBody = string(msg.GetHTML()), string(msg.GetPlainText())
+	case msg.GetMimeType() == mime.TextHTML:
+		pm.HTMLBody = string(msg.GetBody())
+	default:
+		pm.TextBody = string(msg.GetBody())
+	}
+
+	if metadata := messageTags(ctx, o.providerCfg.GetMetadata()); len(metadata) != 0 {
+		pm.Metadata = metadata
+	}
+
+	for _, att := range msg.GetAttachments().GetList() {
+		pa := postmarkAttachment{
+			Name:        contracts.AttachmentFileName(att),
+			Content:     att.GetContent(),
+			ContentType: att.GetMimeType(),
+		}
+
+		if att.GetAttachMethod() == contracts.AttachMethodInline {
+			pa.ContentID = "cid:" + contracts.AttachmentFileName(att)
+		}
+
+		pm.Attachments = append(pm.Attachments, pa)
+	}
+
+	return pm
+}
+
+// classifyPostmarkError classifies Postmark error response by http status and Postmark error code:
+// maintenance (code 100), rate limits and server errors are temporary, others are permanent.
+func classifyPostmarkError(status int, body []byte) error {
+	var resp postmarkResponse
+
+	_ = json.Unmarshal(body, &resp)
+
+	err := fmt.Errorf("%d postmark error %d: %s", status, resp.ErrorCode, resp.Message) // nolint: goerr113
+
+	if resp.ErrorCode == postmarkCodeMaintenance {
+		return mailErrors.Temporary(status, err)
+	}
+
+	return mailErrors.FromHTTPStatus(status, err)
+}
@@ -0,0 +1,225 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostmark_Send(t *testing.T) {
+	trackOpens, noTrackOpens := true, false
+
+	type testCase struct {
+		name string
+		cfg  providers.PostmarkConfig
+		ctx  context.Context
+		in   contracts.Message
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			cfg:  providers.PostmarkConfig{ServerToken: "token"},
+			ctx:  context.Background(),
+			in:   newTestMessage(nil),
+			exp: `{
+				"From":"\"From\" <from@spacetab.io>","To":"\"To\" <to@spacetab.io>","Subject":"Test email",
+				"HtmlBody":"<p>test email content</p>","MessageStream":"outbound"
+			}`,
+		},
+		{
+			name: "inline and file attachments",
+			cfg:  providers.PostmarkConfig{ServerToken: "token"},
+			ctx:  context.Background(),
+			in:   newTestMessage(testAttachments),
+			exp: `{
+				"From":"\"From\" <from@spacetab.io>","To":"\"To\" <to@spacetab.io>","Subject":"Test email",
+				"HtmlBody":"<p>test email content</p>","MessageStream":"outbound",
+				"Attachments":[
+					{"Name":"logo.png","Content":"cG5n","ContentType":"image/png","ContentID":"cid:logo.png"},
+					{"Name":"report.pdf","Content":"cGRm","ContentType":"application/pdf"}
+				]
+			}`,
+		},
+		{
+			name: "broadcast stream with tracking, tag and metadata",
+			cfg: providers.PostmarkConfig{
+				ServerToken:   "token",
+				MessageStream: providers.PostmarkStreamBroadcast,
+				Tag:           "newsletter",
+				Metadata:      map[string]string{"app": "mails", "kind": "default"},
+				TrackOpens:    &trackOpens,
+				TrackLinks:    "HtmlAndText",
+			},
+			ctx: providers.WithTags(context.Background(), map[string]string{"kind": "digest"}),
+			in:  newAlternativeTestMessage(),
+			exp: `{
+				"From":"\"From\" <from@spacetab.io>","To":"\"To\" <to@spacetab.io>","Subject":"Test email",
+				"HtmlBody":"<p>test email content</p>","TextBody":"test email content","MessageStream":"broadcast",
+				"Tag":"newsletter","TrackOpens":true,"TrackLinks":"HtmlAndText","Metadata":{"app":"mails","kind":"digest"}
+			}`,
+		},
+		{
+			// disables open tracking enabled on server
+			name: "open tracking disabled",
+			cfg:  providers.PostmarkConfig{ServerToken: "token", TrackOpens: &noTrackOpens},
+			ctx:  context.Background(),
+			in:   newTestMessage(nil),
+			exp: `{
+				"From":"\"From\" <from@spacetab.io>","To":"\"To\" <to@spacetab.io>","Subject":"Test email",
+				"HtmlBody":"<p>test email content</p>","MessageStream":"outbound","TrackOpens":false
+			}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusOK, "application/json", `{"To":"to@spacetab.io","MessageID":"pm-abc123","ErrorCode":0,"Message":"OK"}`)
+
+			cfg := tc.cfg
+			cfg.Endpoint = api.server.URL
+
+			provider, err := providers.NewPostmark(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in
+
+			res, err := provider.SendWithResult(tc.ctx, &msg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, http.MethodPost, requests[0].method)
+			assert.Equal(t, "/email", requests[0].path)
+			assert.Equal(t, "token", requests[0].header.Get("X-Postmark-Server-Token"))
+			assert.JSONEq(t, tc.exp, string(requests[0].body))
+
+			assert.Equal(t, provider.Name(), res.Provider)
+			assert.Equal(t, "pm-abc123", res.MessageID)
+			assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", MessageID: "pm-abc123", Status: "accepted"}}, res.Accepted)
+		})
+	}
+}
+
+func TestPostmark_SendErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		status   int
+		response string
+		exp      error
+	}
+
+	tcs := []testCase{
+		{name: "inactive recipient", status: http.StatusUnprocessableEntity, response: `{"ErrorCode":406,"Message":"recipient is inactive"}`, exp: mailErrors.ErrPermanent},
+		{name: "invalid token", status: http.StatusUnauthorized, response: `{"ErrorCode":10,"Message":"bad or missing server token"}`, exp: mailErrors.ErrPermanent},
+		{name: "maintenance", status: http.StatusUnprocessableEntity, response: `{"ErrorCode":100,"Message":"maintenance"}`, exp: mailErrors.ErrTemporary},
+		{name: "rate limited", status: http.StatusTooManyRequests, response: `{"ErrorCode":0,"Message":"rate limit exceeded"}`, exp: mailErrors.ErrTemporary},
+		{name: "server error", status: http.StatusInternalServerError, response: ``, exp: mailErrors.ErrTemporary},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "application/json", tc.response)
+
+			provider, err := providers.NewPostmark(providers.PostmarkConfig{ServerToken: "token", Endpoint: api.server.URL})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestPostmark_SendBatch(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusOK, "application/json", `[
+		{"To":"to@spacetab.io","MessageID":"pm-1","ErrorCode":0,"Message":"OK"},
+		{"ErrorCode":406,"Message":"recipient is inactive"}
+	]`)
+
+	provider, err := providers.NewPostmark(providers.PostmarkConfig{ServerToken: "token", Endpoint: api.server.URL})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	first, second, third := newTestMessage(nil), newAlternativeTestMessage(), newTestMessage(nil)
+
+	results, err := provider.SendBatch(context.Background(), []contracts.MessageInterface{&first, &second, &third})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	requests := api.received()
+	if !assert.Len(t, requests, 1) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "/email/batch", requests[0].path)
+
+	if !assert.Len(t, results, 3) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "pm-1", results[0].MessageID)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", MessageID: "pm-1", Status: "accepted"}}, results[0].Accepted)
+	assert.Empty(t, results[1].Accepted)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", Status: "rejected", Reason: "406 recipient is inactive"}}, results[1].Rejected)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", Status: "rejected", Reason: "missing in batch response"}}, results[2].Rejected)
+}
+
+func TestNewPostmark(t *testing.T) {
+	type testCase struct {
+		name  string
+		in    providers.PostmarkConfig
+		isErr bool
+	}
+
+	tcs := []testCase{
+		{name: "valid", in: providers.PostmarkConfig{ServerToken: "token", TrackLinks: "HtmlOnly"}},
+		{name: "no token", in: providers.PostmarkConfig{}, isErr: true},
+		{name: "unknown link tracking", in: providers.PostmarkConfig{ServerToken: "token", TrackLinks: "Always"}, isErr: true},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := providers.NewPostmark(tc.in)
+			if tc.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package providers
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing, process groups are not supported.
+func setProcessGroup(_ *exec.Cmd) {}
+
+// killProcessGroup kills started cmd only.
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
@@ -0,0 +1,111 @@
+package providers_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	cfgmime "github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/stretchr/testify/assert"
+)
+
+// apiTestConfig points provider to test api server.
+type apiTestConfig struct {
+	mailing.MailProviderConfigInterface
+	host string
+}
+
+func (c apiTestConfig) GetHostPort() cfgstructs.AddressInterface {
+	return &cfgstructs.HostCfg{Host: c.host}
+}
+
+type recordedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// apiStandIn is a httptest server impersonating provider api which records received requests.
+type apiStandIn struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	requests []recordedRequest
+	header   http.Header
+}
+
+func newAPIStandIn(t *testing.T, status int, contentType string, response string) *apiStandIn {
+	t.Helper()
+
+	s := &apiStandIn{header: make(http.Header)}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, recordedRequest{method: r.Method, path: r.URL.Path, header: r.Header.Clone(), body: body})
+
+		for k, v := range s.header {
+			w.Header()[k] = v
+		}
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, response)
+	}))
+
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+// setHeader adds response header.
+func (s *apiStandIn) setHeader(key string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.header.Set(key, value)
+}
+
+func (s *apiStandIn) received() []recordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func newTestMessage(attachments contracts.MessageAttachmentList) contracts.Message {
+	return contracts.Message{
+		From:        mailing.MailAddress{Email: "from@spacetab.io", Name: "From"},
+		To:          mailing.MailAddressList{{Email: "to@spacetab.io", Name: "To"}},
+		MimeType:    cfgmime.TextHTML,
+		Subject:     "Test email",
+		Content:     []byte("<p>test email content</p>"),
+		Attachments: attachments,
+	}
+}
+
+func newAlternativeTestMessage() contracts.Message {
+	return contracts.Message{
+		From:      mailing.MailAddress{Email: "from@spacetab.io", Name: "From"},
+		To:        mailing.MailAddressList{{Email: "to@spacetab.io", Name: "To"}},
+		Subject:   "Test email",
+		HTML:      []byte("<p>test email content</p>"),
+		PlainText: []byte("test email content"),
+	}
+}
+
+var testAttachments = contracts.MessageAttachmentList{
+	{MimeType: "image/png", AttachMethod: contracts.AttachMethodInline, Filename: "logo.png", Name: "logo", Content: []byte("png")},
+	{MimeType: "application/pdf", AttachMethod: contracts.AttachMethodFile, Filename: "report.pdf", Name: "report", Content: []byte("pdf")},
+}
@@ -0,0 +1,119 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	cfgErrors "github.com/spacetab-io/configuration-structs-go/v2/errors"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+)
+
+// ProviderFactory builds provider from its config.
+type ProviderFactory func(providerCfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[mailing.MailProviderName]ProviderFactory)
+)
+
+// nolint: gochecknoinits
+func init() {
+	Register(mailing.MailProviderFile, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewFileProvider(cfg)
+	})
+	Register(MailProviderMaildir, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewMaildir(cfg)
+	})
+	Register(MailProviderMbox, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewMbox(cfg)
+	})
+	Register(mailing.MailProviderMailgun, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewMailgun(cfg)
+	})
+	Register(mailing.MailProviderMandrill, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewMandrill(cfg)
+	})
+	Register(mailing.MailProviderSendgrid, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewSendgrid(cfg)
+	})
+	Register(mailing.MailProviderSMTP, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewSMTP(cfg)
+	})
+	Register(MailProviderSES, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewSES(cfg)
+	})
+	Register(MailProviderPostmark, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewPostmark(cfg)
+	})
+	Register(MailProviderUnisenderGo, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewUnisenderGo(cfg)
+	})
+	Register(MailProviderWebhook, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewWebhook(cfg)
+	})
+	Register(MailProviderSendmail, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewSendmail(cfg)
+	})
+	Register(MailProviderMSGraph, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewMSGraph(cfg)
+	})
+	Register(MailProviderSparkPost, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewSparkPost(cfg)
+	})
+	Register(MailProviderMailjet, func(cfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return NewMailjet(cfg)
+	})
+}
+
+// Register makes provider factory available by provider name to New and mails.NewMailing. Built-in providers
+// are registered on package init, other packages register their ones in their init functions.
+// Like sql.Register, it panics if factory is nil or name is already registered.
+func Register(name mailing.MailProviderName, factory ProviderFactory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic("mails: Register provider factory is nil")
+	}
+
+	if _, dup := factories[name]; dup {
+		panic("mails: Register called twice for provider " + name.String())
+	}
+
+	factories[name] = factory
+}
+
+// Registered returns sorted names of registered providers.
+func Registered() []mailing.MailProviderName {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	names := make([]mailing.MailProviderName, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	return names
+}
+
+// New builds provider with factory registered for config provider name.
+func New(providerCfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[providerCfg.Name()]
+	factoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cfgErrors.ErrUnknownProvider, providerCfg.Name())
+	}
+
+	provider, err := factory(providerCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
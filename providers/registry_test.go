@@ -0,0 +1,121 @@
+package providers_test
+
+import (
+	"testing"
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	cfgErrors "github.com/spacetab-io/configuration-structs-go/v2/errors"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+// registryTestConfig is a config of provider registered by test.
+type registryTestConfig struct {
+	providers.MboxConfig
+	name mailing.MailProviderName
+}
+
+func (c registryTestConfig) Name() mailing.MailProviderName {
+	return c.name
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	memory := providers.NewMemory()
+
+	providers.Register("registry-test", func(providerCfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+		return memory, nil
+	})
+
+	provider, err := providers.New(registryTestConfig{name: "registry-test"})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Same(t, memory, provider)
+	assert.Contains(t, providers.Registered(), mailing.MailProviderName("registry-test"))
+
+	assert.Panics(t, func() {
+		providers.Register("registry-test", func(providerCfg mailing.MailProviderConfigInterface) (contracts.ProviderInterface, error) {
+			return memory, nil
+		})
+	})
+	assert.Panics(t, func() { providers.Register("registry-test-nil", nil) })
+}
+
+func TestNew(t *testing.T) {
+	type testCase struct {
+		name  string
+		in    mailing.MailProviderConfigInterface
+		exp   mailing.MailProviderName
+		isErr bool
+		err   error
+	}
+
+	tcs := []testCase{
+		{name: "built-in provider", in: providers.SendmailConfig{}, exp: providers.MailProviderSendmail},
+		{
+			name: "smtp provider",
+			in: providers.SMTPConfig{SMTPConfig: mailing.SMTPConfig{
+				Host:              "127.0.0.1",
+				Port:              25,
+				Encryption:        mailing.MailProviderEncryptionNone,
+				AuthType:          cfgstructs.AuthTypeNone,
+				ConnectionTimeout: time.Second,
+				SendTimeout:       time.Second,
+			}},
+			exp: mailing.MailProviderSMTP,
+		},
+		{name: "unknown provider", in: registryTestConfig{name: "unknown"}, isErr: true, err: cfgErrors.ErrUnknownProvider},
+		{name: "invalid config", in: providers.PostmarkConfig{}, isErr: true},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := providers.New(tc.in)
+			if tc.isErr {
+				assert.Error(t, err)
+				assert.Nil(t, provider)
+
+				if tc.err != nil {
+					assert.ErrorIs(t, err, tc.err)
+				}
+
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, tc.exp, provider.Name())
+		})
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	t.Parallel()
+
+	registered := providers.Registered()
+
+	for _, name := range []mailing.MailProviderName{
+		mailing.MailProviderSMTP,
+		mailing.MailProviderSendgrid,
+		providers.MailProviderSES,
+		providers.MailProviderWebhook,
+		providers.MailProviderMailjet,
+	} {
+		assert.Contains(t, registered, name)
+	}
+
+	assert.IsNonDecreasing(t, registered)
+}
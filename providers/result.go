@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+)
+
+const (
+	recipientStatusAccepted = "accepted"
+	recipientStatusRejected = "rejected"
+)
+
+// newSendResult returns result for providers accepting or rejecting message as a whole.
+func newSendResult(name mailing.MailProviderName, messageID string, msg contracts.MessageInterface) contracts.SendResult {
+	accepted := make([]contracts.RecipientStatus, 0)
+
+	for _, email := range recipients(msg) {
+		accepted = append(accepted, contracts.RecipientStatus{
+			Email:     email,
+			MessageID: messageID,
+			Status:    recipientStatusAccepted,
+		})
+	}
+
+	return contracts.SendResult{
+		Provider:  name,
+		MessageID: messageID,
+		Accepted:  accepted,
+		SentAt:    time.Now(),
+	}
+}
+
+// newRejectedResult returns result of message rejected as a whole with reason.
+func newRejectedResult(name mailing.MailProviderName, reason string, msg contracts.MessageInterface) contracts.SendResult {
+	rejected := make([]contracts.RecipientStatus, 0)
+
+	for _, email := range recipients(msg) {
+		rejected = append(rejected, contracts.RecipientStatus{Email: email, Status: recipientStatusRejected, Reason: reason})
+	}
+
+	return contracts.SendResult{Provider: name, Rejected: rejected, SentAt: time.Now()}
+}
+
+// recipients returns emails of message to, cc and bcc recipients.
+func recipients(msg contracts.MessageInterface) []string {
+	emails := make([]string, 0)
+
+	for _, list := range []mailing.MailAddressListInterface{msg.GetTo(), msg.GetCc(), msg.GetBcc()} {
+		for _, addr := range list.GetList() {
+			emails = append(emails, addr.GetEmail())
+		}
+	}
+
+	return emails
+}
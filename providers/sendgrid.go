@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
 	"github.com/spacetab-io/configuration-structs-go/v2/mime"
 	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
 )
 
 type Sendgrid struct {
@@ -22,7 +24,15 @@ func NewSendgrid(providerCfg mailing.MailProviderConfigInterface) (Sendgrid, err
 		return Sendgrid{}, fmt.Errorf("sendgrid provider config validation error: %w", err)
 	}
 
-	return Sendgrid{client: sendgrid.NewSendClient(providerCfg.GetPassword()), providerCfg: providerCfg}, nil
+	client := sendgrid.NewSendClient(providerCfg.GetPassword())
+
+	if host := configuredHost(providerCfg); host != "" {
+		request := sendgrid.GetRequest(providerCfg.GetPassword(), "/v3/mail/send", host)
+		request.Method = http.MethodPost
+		client = &sendgrid.Client{Request: request}
+	}
+
+	return Sendgrid{client: client, providerCfg: providerCfg}, nil
 }
 
 func (o Sendgrid) Name() mailing.MailProviderName {
@@ -30,15 +40,12 @@ func (o Sendgrid) Name() mailing.MailProviderName {
 }
 
 func (o Sendgrid) Send(ctx context.Context, msg contracts.MessageInterface) error {
-	var content *mail.Content
+	_, err := o.SendWithResult(ctx, msg)
 
-	switch msg.GetMimeType() {
-	case mime.TextHTML, mime.TextPlain:
-		content = mail.NewContent(msg.GetMimeType().String(), string(msg.GetBody()))
-	default:
-		content = mail.NewContent(mime.TextPlain.String(), string(msg.GetBody()))
-	}
+	return err
+}
 
+func (o Sendgrid) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
 	message := mail.NewV3Mail()
 
 	if !msg.GetFrom().IsEmpty() {
@@ -46,7 +53,7 @@ func (o Sendgrid) Send(ctx context.Context, msg contracts.MessageInterface) erro
 	}
 
 	message.AddPersonalizations(o.getPersonalization(msg))
-	message.AddContent(content)
+	message.AddContent(o.getContents(msg)...)
 
 	if !msg.GetReplyTo().IsEmpty() {
 		message.ReplyTo = mail.NewEmail(msg.GetReplyTo().GetName(), msg.GetReplyTo().GetEmail())
@@ -54,18 +61,40 @@ func (o Sendgrid) Send(ctx context.Context, msg contracts.MessageInterface) erro
 
 	message.Subject = msg.GetSubject()
 
-	ctx, cancel := context.WithTimeout(ctx, o.providerCfg.GetSendTimeout())
+	for _, att := range msg.GetAttachments().GetList() {
+		message.AddAttachment(toSendgridAttachment(att))
+	}
+
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
 
 	defer cancel()
 
 	response, err := o.client.SendWithContext(ctx, message)
 	if err == nil && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("sendgrid send message error: %d %s", response.StatusCode, response.Body) //nolint: goerr113
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sendgrid send message error: %w", mailErrors.FromHTTPStatus(
+			response.StatusCode,
+			fmt.Errorf("%d %s", response.StatusCode, response.Body), //nolint: goerr113
+		))
 	} else if err != nil {
-		return fmt.Errorf("sendgrid email send error: %w", err)
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sendgrid email send error: %w", mailErrors.FromTransport(err))
 	}
 
-	return nil
+	return newSendResult(o.Name(), http.Header(response.Headers).Get("X-Message-Id"), msg), nil
+}
+
+// getContents returns message contents. Sendgrid requires text/plain content to be the first one.
+func (o Sendgrid) getContents(msg contracts.MessageInterface) []*mail.Content {
+	switch {
+	case msg.IsAlternative():
+		return []*mail.Content{
+			mail.NewContent(mime.TextPlain.String(), string(msg.GetPlainText())),
+			mail.NewContent(mime.TextHTML.String(), string(msg.GetHTML())),
+		}
+	case msg.GetMimeType() == mime.TextHTML:
+		return []*mail.Content{mail.NewContent(mime.TextHTML.String(), string(msg.GetBody()))}
+	default:
+		return []*mail.Content{mail.NewContent(mime.TextPlain.String(), string(msg.GetBody()))}
+	}
 }
 
 func (o Sendgrid) getPersonalization(msg contracts.MessageInterface) *mail.Personalization {
@@ -104,3 +133,16 @@ func (o Sendgrid) getPersonalization(msg contracts.MessageInterface) *mail.Perso
 
 	return p
 }
+
+func toSendgridAttachment(att contracts.MessageAttachmentInterface) *mail.Attachment {
+	a := mail.NewAttachment().
+		SetContent(base64.StdEncoding.EncodeToString(att.GetContent())).
+		SetType(att.GetMimeType()).
+		SetFilename(contracts.AttachmentFileName(att))
+
+	if att.GetAttachMethod() == contracts.AttachMethodInline {
+		return a.SetDisposition("inline").SetContentID(contracts.AttachmentFileName(att))
+	}
+
+	return a.SetDisposition("attachment")
+}
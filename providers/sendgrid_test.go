@@ -0,0 +1,159 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendgrid_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		in   contracts.Message
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			in:   newTestMessage(nil),
+			exp: `{
+				"from":{"name":"From","email":"from@spacetab.io"},
+				"subject":"Test email",
+				"personalizations":[{"to":[{"name":"To","email":"to@spacetab.io"}],"from":{"name":"From","email":"from@spacetab.io"},"subject":"Test email"}],
+				"content":[{"type":"text/html","value":"<p>test email content</p>"}]
+			}`,
+		},
+		{
+			name: "inline and file attachments",
+			in:   newTestMessage(testAttachments),
+			exp: `{
+				"from":{"name":"From","email":"from@spacetab.io"},
+				"subject":"Test email",
+				"personalizations":[{"to":[{"name":"To","email":"to@spacetab.io"}],"from":{"name":"From","email":"from@spacetab.io"},"subject":"Test email"}],
+				"content":[{"type":"text/html","value":"<p>test email content</p>"}],
+				"attachments":[
+					{"content":"cG5n","type":"image/png","filename":"logo.png","disposition":"inline","content_id":"logo.png"},
+					{"content":"cGRm","type":"application/pdf","filename":"report.pdf","disposition":"attachment"}
+				]
+			}`,
+		},
+		{
+			name: "html and plain text",
+			in:   newAlternativeTestMessage(),
+			exp: `{
+				"from":{"name":"From","email":"from@spacetab.io"},
+				"subject":"Test email",
+				"personalizations":[{"to":[{"name":"To","email":"to@spacetab.io"}],"from":{"name":"From","email":"from@spacetab.io"},"subject":"Test email"}],
+				"content":[{"type":"text/plain","value":"test email content"},{"type":"text/html","value":"<p>test email content</p>"}]
+			}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusAccepted, "application/json", "")
+
+			provider, err := providers.NewSendgrid(apiTestConfig{
+				MailProviderConfigInterface: mailing.SendgridConfig{Key: "key"},
+				host:                        api.server.URL,
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in
+			if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, http.MethodPost, requests[0].method)
+			assert.Equal(t, "/v3/mail/send", requests[0].path)
+			assert.Equal(t, "Bearer key", requests[0].header.Get("Authorization"))
+			assert.JSONEq(t, tc.exp, string(requests[0].body))
+		})
+	}
+}
+
+func TestSendgrid_SendErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		status   int
+		response string
+		exp      error
+	}
+
+	tcs := []testCase{
+		{name: "rate limited", status: http.StatusTooManyRequests, response: `{"errors":[{"message":"too many requests"}]}`, exp: mailErrors.ErrTemporary},
+		{name: "server error", status: http.StatusServiceUnavailable, response: ``, exp: mailErrors.ErrTemporary},
+		{name: "bad request", status: http.StatusBadRequest, response: `{"errors":[{"message":"invalid email"}]}`, exp: mailErrors.ErrPermanent},
+		{name: "unauthorized", status: http.StatusUnauthorized, response: `{"errors":[{"message":"invalid key"}]}`, exp: mailErrors.ErrPermanent},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "application/json", tc.response)
+
+			provider, err := providers.NewSendgrid(apiTestConfig{
+				MailProviderConfigInterface: mailing.SendgridConfig{Key: "key"},
+				host:                        api.server.URL,
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestSendgrid_SendWithResult(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusAccepted, "application/json", "")
+	api.setHeader("X-Message-Id", "sg-abc123")
+
+	provider, err := providers.NewSendgrid(apiTestConfig{
+		MailProviderConfigInterface: mailing.SendgridConfig{Key: "key"},
+		host:                        api.server.URL,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	res, err := provider.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, provider.Name(), res.Provider)
+	assert.Equal(t, "sg-abc123", res.MessageID)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", MessageID: "sg-abc123", Status: "accepted"}}, res.Accepted)
+	assert.Empty(t, res.Rejected)
+	assert.False(t, res.SentAt.IsZero())
+}
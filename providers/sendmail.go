@@ -0,0 +1,110 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+// Sendmail pipes messages to local sendmail compatible binary. Envelope sender is passed with -f,
+// recipients are read by the binary from message headers (including Bcc one, which sendmail removes),
+// so custom Args should keep -t. Process is killed with processes it forked when context is done.
+type Sendmail struct {
+	providerCfg SendmailConfigInterface
+}
+
+func NewSendmail(providerCfg mailing.MailProviderConfigInterface) (Sendmail, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return Sendmail{}, fmt.Errorf("sendmail provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(SendmailConfigInterface)
+	if !ok {
+		return Sendmail{}, fmt.Errorf("sendmail provider config error: %T is not SendmailConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	return Sendmail{providerCfg: cfg}, nil
+}
+
+func (o Sendmail) Name() mailing.MailProviderName {
+	return MailProviderSendmail
+}
+
+func (o Sendmail) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (o Sendmail) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	messageID := eml.NewMessageID(msg.GetFrom().GetDomain())
+
+	body, err := eml.Marshal(msg, eml.Options{MessageID: messageID, IncludeBcc: true})
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sendmail message build error: %w", mailErrors.Permanent(0, err))
+	}
+
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	args := append([]string{}, o.providerCfg.GetArgs()...)
+	if from := msg.GetFrom().GetEmail(); from != "" {
+		args = append(args, "-f", from)
+	}
+
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.Command(o.providerCfg.GetPath(), args...) // nolint: gosec
+	// sendmail reads message lines ending with LF, some MTAs keep or double CR of CRLF line endings
+	cmd.Stdin = bytes.NewReader(bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n")))
+	cmd.Stderr = stderr
+
+	// MTA may fork, e.g. postfix sendmail runs postdrop, and forked processes keep stderr open,
+	// so the whole process group is killed when context is done
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		// binary is missing or is not executable
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sendmail start error: %w", mailErrors.Permanent(0, err))
+	}
+
+	exited := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			killProcessGroup(cmd)
+		case <-exited:
+		}
+	}()
+
+	err = cmd.Wait()
+	close(exited)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sendmail send error: %w", ctx.Err())
+		}
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sendmail send error: %w", mailErrors.Permanent(0, err))
+		}
+
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sendmail send error: %w", mailErrors.FromExitCode(mailErrors.SendmailError{
+			ExitCode: exitErr.ExitCode(),
+			Stderr:   stderr.String(),
+			Err:      err,
+		}))
+	}
+
+	return newSendResult(o.Name(), messageID, msg), nil
+}
@@ -0,0 +1,183 @@
+package providers_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSendmail returns sendmail config running shell script, which stores its arguments and stdin
+// into "args" and "stdin" files of returned directory. Script is run with sh, so it is not written
+// as executable file to avoid "text file busy" errors of parallel tests.
+func fakeSendmail(t *testing.T, script string) (providers.SendmailConfig, string) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("sendmail is not available on windows")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sendmail.sh")
+	content := "#!/bin/sh\n" +
+		`dir=$(dirname "$0")` + "\n" +
+		`printf '%s\n' "$@" > "$dir/args"` + "\n" +
+		`cat > "$dir/stdin"` + "\n" +
+		script + "\n"
+
+	if !assert.NoError(t, os.WriteFile(path, []byte(content), 0o600)) {
+		t.FailNow()
+	}
+
+	return providers.SendmailConfig{Path: "/bin/sh", Args: []string{path, "-t", "-i"}}, dir
+}
+
+func readSendmailFile(t *testing.T, dir string, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return string(content)
+}
+
+func TestSendmail_Send(t *testing.T) {
+	t.Parallel()
+
+	cfg, dir := fakeSendmail(t, "exit 0")
+
+	provider, err := providers.NewSendmail(cfg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(testAttachments)
+	msg.Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+
+	res, err := provider.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "-t\n-i\n-f\nfrom@spacetab.io\n", readSendmailFile(t, dir, "args"))
+
+	stdin := readSendmailFile(t, dir, "stdin")
+	assert.Contains(t, stdin, "\nMessage-ID: <"+res.MessageID+">\n")
+	assert.Contains(t, stdin, "\nSubject: Test email\n")
+	assert.NotContains(t, stdin, "\r")
+
+	parsed, err := eml.Unmarshal([]byte(stdin))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "Test email", parsed.GetSubject())
+	assert.Equal(t, mailing.MailAddressList{{Email: "bcc@spacetab.io"}}, parsed.Bcc)
+	assert.Len(t, parsed.GetAttachments().GetList(), 2)
+
+	assert.Equal(t, provider.Name(), res.Provider)
+	assert.Len(t, res.Accepted, 2)
+}
+
+func TestSendmail_SendErrors(t *testing.T) {
+	type testCase struct {
+		name   string
+		script string
+		exp    error
+		stderr string
+	}
+
+	tcs := []testCase{
+		{name: "temporary failure", script: "echo 'queue is full' >&2; exit 75", exp: mailErrors.ErrTemporary, stderr: "queue is full\n"},
+		{name: "unknown user", script: "echo 'user unknown' >&2; exit 67", exp: mailErrors.ErrPermanent, stderr: "user unknown\n"},
+		{name: "generic failure", script: "exit 1", exp: mailErrors.ErrPermanent},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _ := fakeSendmail(t, tc.script)
+
+			provider, err := providers.NewSendmail(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			err = provider.Send(context.Background(), &msg)
+			assert.ErrorIs(t, err, tc.exp)
+
+			var se mailErrors.SendmailError
+			if assert.ErrorAs(t, err, &se) {
+				assert.Equal(t, tc.stderr, se.Stderr)
+			}
+		})
+	}
+}
+
+func TestSendmail_SendMissingBinary(t *testing.T) {
+	t.Parallel()
+
+	provider, err := providers.NewSendmail(providers.SendmailConfig{Path: filepath.Join(t.TempDir(), "sendmail")})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	assert.ErrorIs(t, provider.Send(context.Background(), &msg), mailErrors.ErrPermanent)
+}
+
+func TestSendmail_SendTimeout(t *testing.T) {
+	type testCase struct {
+		name   string
+		script string
+	}
+
+	tcs := []testCase{
+		{name: "sendmail process", script: "exec sleep 10"},
+		// forked sleep keeps stderr open after sendmail process is killed
+		{name: "forked process", script: "sleep 10"},
+		{name: "forked background process", script: "sleep 10 &\nwait"},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, _ := fakeSendmail(t, tc.script)
+			cfg.SendTimeout = 100 * time.Millisecond
+
+			provider, err := providers.NewSendmail(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+			start := time.Now()
+
+			err = provider.Send(context.Background(), &msg)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Less(t, time.Since(start), time.Second)
+			assert.False(t, mailErrors.IsPermanent(err))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const (
+	sesService  = "ses"
+	sesSendPath = "/v2/email/outbound-emails"
+)
+
+// SES sends messages with Amazon SES v2 api as raw MIME, so attachments and custom headers are kept as is.
+type SES struct {
+	client      *http.Client
+	endpoint    string
+	providerCfg SESConfigInterface
+}
+
+func NewSES(providerCfg mailing.MailProviderConfigInterface) (SES, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return SES{}, fmt.Errorf("ses provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(SESConfigInterface)
+	if !ok {
+		return SES{}, fmt.Errorf("ses provider config error: %T is not SESConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	return SES{
+		client:      &http.Client{},
+		endpoint:    strings.TrimSuffix(cfg.GetHostPort().String(), "/"),
+		providerCfg: cfg,
+	}, nil
+}
+
+func (o SES) Name() mailing.MailProviderName {
+	return "sesAPI"
+}
+
+func (o SES) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+type sesTag struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type sesDestination struct {
+	ToAddresses  []string `json:"ToAddresses,omitempty"`
+	CcAddresses  []string `json:"CcAddresses,omitempty"`
+	BccAddresses []string `json:"BccAddresses,omitempty"`
+}
+
+type sesSendEmailRequest struct {
+	FromEmailAddress     string         `json:"FromEmailAddress,omitempty"`
+	Destination          sesDestination `json:"Destination"`
+	ReplyToAddresses     []string       `json:"ReplyToAddresses,omitempty"`
+	Content              sesContent     `json:"Content"`
+	ConfigurationSetName string         `json:"ConfigurationSetName,omitempty"`
+	EmailTags            []sesTag       `json:"EmailTags,omitempty"`
+}
+
+type sesContent struct {
+	Raw struct {
+		// Data is base64 encoded by json marshaller.
+		Data []byte `json:"Data"`
+	} `json:"Raw"`
+}
+
+type sesSendEmailResponse struct {
+	MessageID string `json:"MessageId"`
+}
+
+type sesErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func (o SES) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	raw, err := eml.Marshal(msg, eml.Options{Headers: o.providerCfg.GetHeaders()})
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("ses message build error: %w", mailErrors.Permanent(0, err))
+	}
+
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	req, body, err := newJSONRequest(ctx, o.endpoint+sesSendPath, o.toSESRequest(ctx, msg, raw))
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("ses request error: %w", err)
+	}
+
+	signV4(req, body, sigV4Credentials{
+		accessKeyID:     o.providerCfg.GetUsername(),
+		secretAccessKey: o.providerCfg.GetPassword(),
+		sessionToken:    o.providerCfg.GetSessionToken(),
+	}, o.providerCfg.GetRegion(), sesService, time.Now())
+
+	resp, respBody, err := doRequest(o.client, req)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("ses email send error: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("ses send message error: %w", classifySESError(resp, respBody))
+	}
+
+	var sesResp sesSendEmailResponse
+	if err := json.Unmarshal(respBody, &sesResp); err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("ses response decode error: %w", err)
+	}
+
+	return newSendResult(o.Name(), sesResp.MessageID, msg), nil
+}
+
+func (o SES) toSESRequest(ctx context.Context, msg contracts.MessageInterface, raw []byte) sesSendEmailRequest {
+	req := sesSendEmailRequest{
+		FromEmailAddress: msg.GetFrom().String(),
+		Destination: sesDestination{
+			ToAddresses:  addressList(msg.GetTo()),
+			CcAddresses:  addressList(msg.GetCc()),
+			BccAddresses: addressList(msg.GetBcc()),
+		},
+		ConfigurationSetName: o.providerCfg.GetConfigurationSet(),
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		req.ReplyToAddresses = []string{msg.GetReplyTo().String()}
+	}
+
+	req.Content.Raw.Data = raw
+
+	tags := messageTags(ctx, o.providerCfg.GetTags())
+
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		req.EmailTags = append(req.EmailTags, sesTag{Name: name, Value: tags[name]})
+	}
+
+	return req
+}
+
+// addressList returns string list of nullable address list.
+func addressList(list mailing.MailAddressListInterface) []string {
+	if list == nil || list.IsEmpty() {
+		return nil
+	}
+
+	return list.GetStringList()
+}
+
+// classifySESError classifies SES error response by status code, throttling errors are temporary.
+func classifySESError(resp *http.Response, body []byte) error {
+	var sesErr sesErrorResponse
+
+	_ = json.Unmarshal(body, &sesErr)
+
+	errType := strings.SplitN(resp.Header.Get("X-Amzn-Errortype"), ":", 2)[0] // nolint: gomnd
+	err := fmt.Errorf("%d %s: %s", resp.StatusCode, errType, sesErr.Message)  // nolint: goerr113
+
+	if strings.Contains(errType, "Throttling") || strings.Contains(errType, "TooManyRequests") {
+		return mailErrors.Temporary(resp.StatusCode, err)
+	}
+
+	return mailErrors.FromHTTPStatus(resp.StatusCode, err)
+}
@@ -0,0 +1,210 @@
+package providers_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+var sigV4AuthRe = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/([^/]+)/aws4_request, SignedHeaders=([^,]+), Signature=([0-9a-f]{64})$`)
+
+// checkSigV4 recomputes request signature the way aws does and compares it with the sent one.
+func checkSigV4(t *testing.T, r recordedRequest, host string, secret string) {
+	t.Helper()
+
+	m := sigV4AuthRe.FindStringSubmatch(r.header.Get("Authorization"))
+	if !assert.NotNil(t, m, "authorization header format") {
+		return
+	}
+
+	date, region, service, signedHeaders, signature := m[2], m[3], m[4], m[5], m[6]
+
+	canonicalHeaders := ""
+	for _, name := range strings.Split(signedHeaders, ";") {
+		value := r.header.Get(name)
+		if name == "host" {
+			value = host
+		}
+
+		canonicalHeaders += name + ":" + value + "\n"
+	}
+
+	hash := func(data string) string {
+		sum := sha256.Sum256([]byte(data))
+
+		return hex.EncodeToString(sum[:])
+	}
+
+	mac := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		_, _ = h.Write([]byte(data))
+
+		return h.Sum(nil)
+	}
+
+	canonicalRequest := strings.Join([]string{r.method, r.path, "", canonicalHeaders, signedHeaders, hash(string(r.body))}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + r.header.Get("X-Amz-Date") + "\n" + scope + "\n" + hash(canonicalRequest)
+	key := mac(mac(mac(mac([]byte("AWS4"+secret), date), region), service), "aws4_request")
+
+	assert.Equal(t, hex.EncodeToString(mac(key, stringToSign)), signature)
+}
+
+type sesRequest struct {
+	FromEmailAddress string
+	Destination      struct {
+		ToAddresses  []string
+		CcAddresses  []string
+		BccAddresses []string
+	}
+	ReplyToAddresses     []string
+	ConfigurationSetName string
+	EmailTags            []struct{ Name, Value string }
+	Content              struct{ Raw struct{ Data []byte } }
+}
+
+func TestSES_Send(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusOK, "application/json", `{"MessageId":"ses-abc123"}`)
+
+	provider, err := providers.NewSES(providers.SESConfig{
+		Region:           "eu-west-1",
+		AccessKeyID:      "AKIDEXAMPLE",
+		SecretAccessKey:  "secret",
+		SessionToken:     "token",
+		Endpoint:         api.server.URL,
+		ConfigurationSet: "transactional",
+		Tags:             map[string]string{"app": "mails", "kind": "default"},
+		Headers:          map[string]string{"List-Unsubscribe": "<https://spacetab.io/unsubscribe>"},
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(testAttachments.Clone())
+	msg.Bcc = append(msg.Bcc, msg.To[0])
+	msg.Bcc[0].Email = "bcc@spacetab.io"
+
+	ctx := providers.WithTags(context.Background(), map[string]string{"kind": "welcome"})
+
+	res, err := provider.SendWithResult(ctx, &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	requests := api.received()
+	if !assert.Len(t, requests, 1) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, http.MethodPost, requests[0].method)
+	assert.Equal(t, "/v2/email/outbound-emails", requests[0].path)
+	assert.Equal(t, "token", requests[0].header.Get("X-Amz-Security-Token"))
+
+	u, _ := url.Parse(api.server.URL)
+	checkSigV4(t, requests[0], u.Host, "secret")
+	assert.Contains(t, requests[0].header.Get("Authorization"), "Credential=AKIDEXAMPLE/")
+	assert.Contains(t, requests[0].header.Get("Authorization"), "/eu-west-1/ses/aws4_request")
+
+	var req sesRequest
+	if !assert.NoError(t, json.Unmarshal(requests[0].body, &req)) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, `"From" <from@spacetab.io>`, req.FromEmailAddress)
+	assert.Equal(t, []string{`"To" <to@spacetab.io>`}, req.Destination.ToAddresses)
+	assert.Empty(t, req.Destination.CcAddresses)
+	assert.Equal(t, []string{`"To" <bcc@spacetab.io>`}, req.Destination.BccAddresses)
+	assert.Equal(t, "transactional", req.ConfigurationSetName)
+	assert.Equal(t, []struct{ Name, Value string }{{"app", "mails"}, {"kind", "welcome"}}, req.EmailTags)
+
+	raw, err := eml.Unmarshal(req.Content.Raw.Data)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "Test email", raw.GetSubject())
+	assert.Equal(t, "<p>test email content</p>", string(raw.GetHTML()))
+	assert.Len(t, raw.GetAttachments().GetList(), 2)
+	assert.Contains(t, string(req.Content.Raw.Data), "List-Unsubscribe: <https://spacetab.io/unsubscribe>\r\n")
+	assert.NotContains(t, string(req.Content.Raw.Data), "bcc@spacetab.io")
+
+	assert.Equal(t, provider.Name(), res.Provider)
+	assert.Equal(t, "ses-abc123", res.MessageID)
+	assert.Equal(t, []contracts.RecipientStatus{
+		{Email: "to@spacetab.io", MessageID: "ses-abc123", Status: "accepted"},
+		{Email: "bcc@spacetab.io", MessageID: "ses-abc123", Status: "accepted"},
+	}, res.Accepted)
+}
+
+func TestSES_SendErrors(t *testing.T) {
+	type testCase struct {
+		name      string
+		status    int
+		errorType string
+		response  string
+		exp       error
+	}
+
+	tcs := []testCase{
+		{name: "too many requests", status: http.StatusTooManyRequests, errorType: "TooManyRequestsException", response: `{"message":"rate exceeded"}`, exp: mailErrors.ErrTemporary},
+		{name: "throttling", status: http.StatusBadRequest, errorType: "ThrottlingException:http://internal.amazon.com/coral/", response: `{"message":"rate exceeded"}`, exp: mailErrors.ErrTemporary},
+		{name: "server error", status: http.StatusInternalServerError, errorType: "InternalFailure", response: ``, exp: mailErrors.ErrTemporary},
+		{name: "message rejected", status: http.StatusBadRequest, errorType: "MessageRejected", response: `{"message":"email address is not verified"}`, exp: mailErrors.ErrPermanent},
+		{name: "bad signature", status: http.StatusForbidden, errorType: "SignatureDoesNotMatch", response: `{"message":"signature does not match"}`, exp: mailErrors.ErrPermanent},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "application/json", tc.response)
+			api.setHeader("X-Amzn-ErrorType", tc.errorType)
+
+			provider, err := providers.NewSES(providers.SESConfig{
+				Region:          "eu-west-1",
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "secret",
+				Endpoint:        api.server.URL,
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			err = provider.Send(context.Background(), &msg)
+			assert.ErrorIs(t, err, tc.exp)
+
+			if tc.errorType != "" {
+				assert.Contains(t, err.Error(), strings.SplitN(tc.errorType, ":", 2)[0])
+			}
+		})
+	}
+}
+
+func TestNewSES(t *testing.T) {
+	t.Parallel()
+
+	_, err := providers.NewSES(providers.SESConfig{Region: "eu-west-1"})
+	assert.Error(t, err)
+
+	assert.Equal(t, "https://email.eu-west-1.amazonaws.com", providers.SESConfig{Region: "eu-west-1"}.GetHostPort().String())
+}
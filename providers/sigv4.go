@@ -0,0 +1,109 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4DateFormat = "20060102T150405Z"
+)
+
+// sigV4Credentials are aws credentials used to sign requests with Signature Version 4.
+type sigV4Credentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// signV4 adds X-Amz-Date, X-Amz-Security-Token (if there is session token) and Authorization headers to req.
+// Request Host, Content-Type and X-Amz-* headers are signed.
+func signV4(req *http.Request, body []byte, creds sigV4Credentials, region string, service string, now time.Time) {
+	amzDate := now.UTC().Format(sigV4DateFormat)
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	if creds.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	signedHeaders, canonicalHeaders := sigV4Headers(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		sigV4Path(req),
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders,
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{sigV4Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), date)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	req.Header.Set("Authorization", sigV4Algorithm+
+		" Credential="+creds.accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+hex.EncodeToString(hmacSHA256(key, stringToSign)))
+}
+
+// sigV4Headers returns signed header names list and canonical headers block.
+func sigV4Headers(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	if req.Host != "" {
+		headers["host"] = req.Host
+	}
+
+	for k, v := range req.Header {
+		name := strings.ToLower(k)
+		if name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.Join(strings.Fields(strings.Join(v, ",")), " ")
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	canonical := &strings.Builder{}
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	return strings.Join(names, ";"), canonical.String()
+}
+
+func sigV4Path(req *http.Request) string {
+	if p := req.URL.EscapedPath(); p != "" {
+		return p
+	}
+
+	return "/"
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
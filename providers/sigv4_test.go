@@ -0,0 +1,54 @@
+package providers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSignV4 checks signer against aws signature version 4 test suite vectors.
+func TestSignV4(t *testing.T) {
+	type testCase struct {
+		name    string
+		method  string
+		expAuth string
+	}
+
+	tcs := []testCase{
+		{
+			name:   "get-vanilla",
+			method: http.MethodGet,
+			expAuth: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:   "post-vanilla",
+			method: http.MethodPost,
+			expAuth: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, " +
+				"Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(tc.method, "https://example.amazonaws.com/", http.NoBody) // nolint: noctx
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			providers.SignV4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service",
+				time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+			assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+			assert.Equal(t, tc.expAuth, req.Header.Get("Authorization"))
+		})
+	}
+}
@@ -3,18 +3,24 @@ package providers
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/textproto"
 
 	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
 	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
 	customMime "github.com/spacetab-io/configuration-structs-go/v2/mime"
 	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
 	"github.com/toorop/go-dkim"
 	mail "github.com/xhit/go-simple-mail/v2"
 )
 
+// SMTP sends messages over pooled smtp connections, see SMTPConfig for pool settings. Connections are dialed
+// on first send, so provider is built without smtp server being available. SMTP is safe for concurrent use.
 type SMTP struct {
-	client      *mail.SMTPClient
+	pool        *smtpPool
 	providerCfg mailing.MailProviderConfigInterface
 }
 
@@ -39,14 +45,13 @@ func NewSMTP(providerCfg mailing.MailProviderConfigInterface) (SMTP, error) {
 	// - None
 	server.Authentication = toProviderAuthType(providerCfg.GetAuthType())
 
-	// Variable to keep alive connection
-	server.KeepAlive = false
+	// Connections are kept open after send, so pool reuses them
+	server.KeepAlive = true
 
-	// Timeout for connect to SMTP Server
-	server.ConnectTimeout = providerCfg.GetConnectionTimeout()
-
-	// Timeout for send the data and wait respond
-	server.SendTimeout = providerCfg.GetSendTimeout()
+	// Connect and send timeouts are applied with send context, which closes connection once it is done
+	// instead of leaving library goroutine blocked on it
+	server.ConnectTimeout = 0
+	server.SendTimeout = 0
 
 	if providerCfg.GetEncryption() == mailing.MailProviderEncryptionNone {
 		// Set TLSConfig to provide custom TLS configuration. For example,
@@ -54,22 +59,33 @@ func NewSMTP(providerCfg mailing.MailProviderConfigInterface) (SMTP, error) {
 		server.TLSConfig = &tls.Config{InsecureSkipVerify: true} //nolint: gosec
 	}
 
-	// SMTP client
-	smtpClient, err := server.Connect()
-	if err != nil {
-		return SMTP{}, fmt.Errorf("smtp server connection error: %w", err)
+	maxConnections, idleTimeout := smtpDefaultMaxConnections, smtpDefaultIdleTimeout
+	if poolCfg, ok := providerCfg.(SMTPPoolConfigInterface); ok {
+		maxConnections, idleTimeout = poolCfg.GetMaxConnections(), poolCfg.GetIdleTimeout()
 	}
 
-	return SMTP{client: smtpClient, providerCfg: providerCfg}, nil
+	return SMTP{
+		pool:        newSMTPPool(server, providerCfg.GetConnectionTimeout(), maxConnections, idleTimeout),
+		providerCfg: providerCfg,
+	}, nil
 }
 
 func (o SMTP) Name() mailing.MailProviderName {
 	return "smtp"
 }
 
-func (o SMTP) Send(_ context.Context, msg contracts.MessageInterface) error {
+func (o SMTP) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+func (o SMTP) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	messageID := eml.NewMessageID(msg.GetFrom().GetDomain())
+
 	// New email simple html with inline and CC
 	email := mail.NewMSG().SetSubject(msg.GetSubject()).SetFrom(msg.GetFrom().String())
+	email.AddHeader("Message-ID", "<"+messageID+">")
 
 	tos := make([]string, 0, len(msg.GetTo().GetList()))
 	for _, to := range msg.GetTo().GetList() {
@@ -96,27 +112,25 @@ func (o SMTP) Send(_ context.Context, msg contracts.MessageInterface) error {
 		email.AddBcc(bccs...)
 	}
 
-	mime := mail.TextPlain
-
-	if msg.GetMimeType() == customMime.TextHTML {
-		mime = mail.TextHTML
+	// alternative text part goes first, so clients prefer html one.
+	switch {
+	case msg.IsAlternative():
+		email.SetBodyData(mail.TextPlain, msg.GetPlainText())
+		email.AddAlternativeData(mail.TextHTML, msg.GetHTML())
+	case msg.GetMimeType() == customMime.TextHTML:
+		email.SetBodyData(mail.TextHTML, msg.GetBody())
+	default:
+		email.SetBodyData(mail.TextPlain, msg.GetBody())
 	}
 
-	email.SetBody(mime, string(msg.GetBody()))
-
-	// also you can add body from []byte with SetBodyData, example:
-	// email.SetBodyData(mail.TextHTML, []byte(htmlBody))
-	// or alternative part
-	// email.AddAlternativeData(mail.TextHTML, []byte(htmlBody))
-
-	// add inline
-	email.Attach(&mail.File{FilePath: "/path/to/image.png", Name: "Gopher.png", Inline: true})
+	for _, att := range msg.GetAttachments().GetList() {
+		email.Attach(toSMTPFile(att))
+	}
 
 	// you can add dkim signature to the email.
 	// to add dkim, you need a private key already created one.
-	if o.providerCfg.GetDKIMPrivateKey() != nil {
+	if k := o.providerCfg.GetDKIMPrivateKey(); k != nil && *k != "" {
 		options := dkim.NewSigOptions()
-		k := o.providerCfg.GetDKIMPrivateKey()
 		options.PrivateKey = []byte(*k)
 		options.Domain = msg.GetFrom().GetDomain()
 		options.Selector = "default"
@@ -128,19 +142,77 @@ func (o SMTP) Send(_ context.Context, msg contracts.MessageInterface) error {
 		email.SetDkim(options)
 	}
 
+	// message build errors are not going to disappear on retry
+	if email.Error != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("smtp email build error: %w", mailErrors.Permanent(0, email.Error))
+	}
+
+	// send timeout covers waiting for connection and its check too
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	client, err := o.pool.get(ctx)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("smtp server connection error: %w", err)
+	}
+
 	// Call Send and pass the client
-	if err := email.Send(o.client); err != nil {
-		return fmt.Errorf("smtp email send error: %w", err)
+	err = smtpDo(ctx, client, func() error { return email.Send(client) })
+
+	o.pool.release(ctx, client, err)
+
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("smtp email send error: %w", classifySMTPError(err))
 	}
 
 	// always check error after send
 	if email.Error != nil {
-		return email.Error
+		return contracts.SendResult{Provider: o.Name()}, email.Error
 	}
 
+	return newSendResult(o.Name(), messageID, msg), nil
+}
+
+// Close closes idle connections, sends after Close fail with errors.ErrProviderClosed.
+func (o SMTP) Close() error {
+	o.pool.close()
+
 	return nil
 }
 
+// classifySMTPError classifies send error by smtp server reply code.
+// Errors without reply code are connection failures, so they are temporary; context cancellation is left unclassified.
+func classifySMTPError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) {
+		return mailErrors.FromSMTPCode(tpErr.Code, err)
+	}
+
+	return mailErrors.Temporary(0, err)
+}
+
+// isSMTPReply reports whether err is smtp server error reply.
+func isSMTPReply(err error) bool {
+	var tpErr *textproto.Error
+
+	return errors.As(err, &tpErr)
+}
+
+// toSMTPFile maps message attachment into in-memory go-simple-mail file.
+func toSMTPFile(att contracts.MessageAttachmentInterface) *mail.File {
+	return &mail.File{
+		Name:     contracts.AttachmentFileName(att),
+		MimeType: att.GetMimeType(),
+		Data:     att.GetContent(),
+		Inline:   att.GetAttachMethod() == contracts.AttachMethodInline,
+	}
+}
+
 func toProviderAuthType(pat cfgstructs.AuthType) (at mail.AuthType) {
 	switch pat {
 	case cfgstructs.AuthTypePlain:
@@ -0,0 +1,269 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	mail "github.com/xhit/go-simple-mail/v2"
+)
+
+// smtpCheckTimeout bounds NOOP and RSET commands when connect timeout is not set, so a silently dropped
+// connection doesn't block send.
+const smtpCheckTimeout = 10 * time.Second
+
+// smtpPool keeps open smtp connections between sends. Connections are dialed on demand up to maxConnections,
+// sends wait for a free one. Idle connections are checked with NOOP before reuse and closed after idle timeout,
+// broken ones are dropped, so the next send dials a new connection.
+type smtpPool struct {
+	server         *mail.SMTPServer
+	connectTimeout time.Duration
+	idleTimeout    time.Duration
+	// slots limits number of open connections, every taken connection holds a slot.
+	slots chan struct{}
+
+	mu   sync.Mutex
+	idle []smtpIdleConn
+	// reaper closes expired idle connections, it is set while there are idle connections.
+	reaper *time.Timer
+	closed bool
+}
+
+type smtpIdleConn struct {
+	client    *mail.SMTPClient
+	idleSince time.Time
+}
+
+func newSMTPPool(server *mail.SMTPServer, connectTimeout time.Duration, maxConnections int, idleTimeout time.Duration) *smtpPool {
+	return &smtpPool{
+		server:         server,
+		connectTimeout: connectTimeout,
+		idleTimeout:    idleTimeout,
+		slots:          make(chan struct{}, maxConnections),
+	}
+}
+
+// get returns healthy idle connection or dials a new one. Connection is returned into pool with put or release.
+func (p *smtpPool) get(ctx context.Context) (*mail.SMTPClient, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("smtp connection wait error: %w", ctx.Err())
+	}
+
+	for {
+		conn, ok, err := p.popIdle()
+		if err != nil {
+			<-p.slots
+
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		if time.Since(conn.idleSince) < p.idleTimeout && p.check(ctx, conn.client, conn.client.Noop) == nil && ctx.Err() == nil {
+			return conn.client, nil
+		}
+
+		_ = conn.client.Close()
+	}
+
+	client, err := p.dial(ctx)
+	if err != nil {
+		<-p.slots
+
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// dial connects to smtp server, passing hello, STARTTLS and AUTH, within connect timeout. Library can't interrupt
+// connect, so on ctx done dial returns at once and connection is closed when it is finally established.
+func (p *smtpPool) dial(ctx context.Context) (*mail.SMTPClient, error) {
+	ctx, cancel := withSendTimeout(ctx, p.connectTimeout)
+
+	defer cancel()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("smtp connect error: %w", err)
+	}
+
+	type dialResult struct {
+		client *mail.SMTPClient
+		err    error
+	}
+
+	done := make(chan dialResult, 1)
+
+	go func() {
+		client, err := p.server.Connect()
+		done <- dialResult{client: client, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, classifySMTPError(r.err)
+		}
+
+		return r.client, nil
+	case <-ctx.Done():
+		go func() {
+			if r := <-done; r.err == nil {
+				_ = r.client.Close()
+			}
+		}()
+
+		return nil, fmt.Errorf("smtp connect error: %w", ctx.Err())
+	}
+}
+
+// popIdle returns the most recently used idle connection.
+func (p *smtpPool) popIdle() (smtpIdleConn, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return smtpIdleConn{}, false, mailErrors.Permanent(0, mailErrors.ErrProviderClosed)
+	}
+
+	if len(p.idle) == 0 {
+		return smtpIdleConn{}, false, nil
+	}
+
+	conn := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+
+	return conn, true, nil
+}
+
+// put returns connection taken with get into pool, connections which are not reusable are closed.
+// Connections idle for longer than idle timeout are closed as well.
+func (p *smtpPool) put(client *mail.SMTPClient, reusable bool) {
+	defer func() { <-p.slots }()
+
+	p.mu.Lock()
+
+	if !reusable || p.closed {
+		p.mu.Unlock()
+
+		_ = client.Close()
+
+		return
+	}
+
+	now := time.Now()
+	closing := p.popExpired(now)
+	p.idle = append(p.idle, smtpIdleConn{client: client, idleSince: now})
+
+	if p.reaper == nil {
+		p.reaper = time.AfterFunc(p.idleTimeout, p.reap)
+	}
+
+	p.mu.Unlock()
+
+	for _, conn := range closing {
+		_ = conn.client.Close()
+	}
+}
+
+// reap closes expired idle connections, so they are not left open until the next send, and schedules
+// itself for the next idle connection expiry.
+func (p *smtpPool) reap() {
+	p.mu.Lock()
+
+	now := time.Now()
+	closing := p.popExpired(now)
+	p.reaper = nil
+
+	if len(p.idle) != 0 && !p.closed {
+		p.reaper = time.AfterFunc(p.idleTimeout-now.Sub(p.idle[0].idleSince), p.reap)
+	}
+
+	p.mu.Unlock()
+
+	for _, conn := range closing {
+		_ = conn.client.Close()
+	}
+}
+
+// popExpired removes idle connections expired at now from pool and returns them. Must be called with mu held.
+func (p *smtpPool) popExpired(now time.Time) []smtpIdleConn {
+	// idle connections are ordered by idle time, so expired ones are at the beginning
+	expired := 0
+	for expired < len(p.idle) && now.Sub(p.idle[expired].idleSince) >= p.idleTimeout {
+		expired++
+	}
+
+	closing := append([]smtpIdleConn(nil), p.idle[:expired]...)
+	p.idle = append(p.idle[:0:0], p.idle[expired:]...)
+
+	return closing
+}
+
+// release returns connection used for send into pool. Connection is reused after successful send or after server
+// rejected message and its transaction is reset, unless it was closed on ctx done.
+func (p *smtpPool) release(ctx context.Context, client *mail.SMTPClient, sendErr error) {
+	reusable := sendErr == nil || isSMTPReply(sendErr) && p.check(ctx, client, client.Reset) == nil
+
+	p.put(client, reusable && ctx.Err() == nil)
+}
+
+// close quits idle connections and makes pool close connections returned into it.
+func (p *smtpPool) close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle, p.closed = nil, true
+
+	if p.reaper != nil {
+		p.reaper.Stop()
+		p.reaper = nil
+	}
+
+	p.mu.Unlock()
+
+	for _, conn := range idle {
+		_ = conn.client.Quit()
+		_ = conn.client.Close()
+	}
+}
+
+// check runs NOOP or RSET fn within connect timeout, as send context may have no deadline.
+func (p *smtpPool) check(ctx context.Context, client *mail.SMTPClient, fn func() error) error {
+	timeout := p.connectTimeout
+	if timeout <= 0 {
+		timeout = smtpCheckTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	defer cancel()
+
+	return smtpDo(ctx, client, fn)
+}
+
+// smtpDo runs fn on client connection and closes the connection once ctx is done, which interrupts blocked reads
+// and writes. fn result is returned if it completed anyway, e.g. server accepted message right before ctx is done.
+func smtpDo(ctx context.Context, client *mail.SMTPClient, fn func() error) error {
+	done := make(chan error, 1)
+
+	go func() { done <- fn() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		_ = client.Close()
+
+		if err := <-done; err == nil {
+			return nil
+		}
+
+		return ctx.Err() // nolint: wrapcheck
+	}
+}
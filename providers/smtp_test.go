@@ -0,0 +1,639 @@
+package providers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/spacetab-io/mails-go/smtptest"
+	"github.com/stretchr/testify/assert"
+)
+
+// receivedData returns raw data of messages received by server.
+func receivedData(s *smtptest.Server) [][]byte {
+	messages := make([][]byte, 0)
+	for _, e := range s.Envelopes() {
+		messages = append(messages, e.Data)
+	}
+
+	return messages
+}
+
+type receivedPart struct {
+	contentType string
+	disposition string
+	filename    string
+	contentID   string
+	content     []byte
+}
+
+// collectParts walks mime tree and returns all leaf parts.
+func collectParts(t *testing.T, contentType string, body io.Reader) []receivedPart {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		content, err := io.ReadAll(body)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		return []receivedPart{{contentType: mediaType, content: content}}
+	}
+
+	parts := make([]receivedPart, 0)
+	mr := multipart.NewReader(body, params["boundary"])
+
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		pType := p.Header.Get("Content-Type")
+		if strings.HasPrefix(pType, "multipart/") {
+			parts = append(parts, collectParts(t, pType, p)...)
+
+			continue
+		}
+
+		pMediaType, _, _ := mime.ParseMediaType(pType)
+
+		var content []byte
+
+		raw, err := io.ReadAll(p)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		if strings.EqualFold(p.Header.Get("Content-Transfer-Encoding"), "base64") {
+			content, err = base64.StdEncoding.DecodeString(strings.NewReplacer("\r", "", "\n", "").Replace(string(raw)))
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+		} else {
+			content = raw
+		}
+
+		disposition, dParams, _ := mime.ParseMediaType(p.Header.Get("Content-Disposition"))
+
+		parts = append(parts, receivedPart{
+			contentType: pMediaType,
+			disposition: disposition,
+			filename:    dParams["filename"],
+			contentID:   strings.Trim(p.Header.Get("Content-ID"), "<>"),
+			content:     content,
+		})
+	}
+
+	return parts
+}
+
+func TestSMTP_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		in   contracts.Message
+		exp  []receivedPart
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			in:   newTestMessage(nil),
+			exp: []receivedPart{
+				{contentType: "text/html", content: []byte("<p>test email content</p>")},
+			},
+		},
+		{
+			name: "file attachment",
+			in: newTestMessage(contracts.MessageAttachmentList{
+				{MimeType: "text/plain", AttachMethod: contracts.AttachMethodFile, Filename: "test.txt", Name: "test", Content: []byte("some content")},
+			}),
+			exp: []receivedPart{
+				{contentType: "text/html", content: []byte("<p>test email content</p>")},
+				{contentType: "text/plain", disposition: "attachment", filename: "test.txt", content: []byte("some content")},
+			},
+		},
+		{
+			name: "inline and file attachments",
+			in: newTestMessage(contracts.MessageAttachmentList{
+				{MimeType: "image/png", AttachMethod: contracts.AttachMethodInline, Filename: "logo.png", Name: "logo", Content: []byte{0x89, 'P', 'N', 'G'}},
+				{MimeType: "application/pdf", AttachMethod: contracts.AttachMethodFile, Filename: "report.pdf", Name: "report", Content: []byte("%PDF-1.4")},
+			}),
+			exp: []receivedPart{
+				{contentType: "text/html", content: []byte("<p>test email content</p>")},
+				{contentType: "image/png", disposition: "inline", filename: "logo.png", content: []byte{0x89, 'P', 'N', 'G'}},
+				{contentType: "application/pdf", disposition: "attachment", filename: "report.pdf", content: []byte("%PDF-1.4")},
+			},
+		},
+		{
+			name: "html and plain text",
+			in:   newAlternativeTestMessage(),
+			exp: []receivedPart{
+				{contentType: "text/plain", content: []byte("test email content")},
+				{contentType: "text/html", content: []byte("<p>test email content</p>")},
+			},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := smtptest.Start(t, smtptest.Config{})
+
+			provider, err := providers.NewSMTP(server.SMTPConfig())
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in
+
+			if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+				t.FailNow()
+			}
+
+			received := receivedData(server)
+			if !assert.Len(t, received, 1) {
+				t.FailNow()
+			}
+
+			parsed, err := mail.ReadMessage(bytes.NewReader(received[0]))
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			parts := collectParts(t, parsed.Header.Get("Content-Type"), parsed.Body)
+
+			for i := range parts {
+				// content id is generated by smtp library, so only check it is set for inline parts
+				if parts[i].disposition == "inline" {
+					assert.NotEmpty(t, parts[i].contentID)
+				}
+
+				parts[i].contentID = ""
+				parts[i].content = bytes.TrimRight(parts[i].content, "\r\n")
+			}
+
+			assert.Equal(t, tc.exp, parts)
+		})
+	}
+}
+
+func TestSMTP_SendErrors(t *testing.T) {
+	type testCase struct {
+		name  string
+		verb  string
+		reply string
+		exp   error
+	}
+
+	tcs := []testCase{
+		{name: "mailbox busy", verb: "RCPT", reply: "450 4.2.1 mailbox busy", exp: mailErrors.ErrTemporary},
+		{name: "no such user", verb: "RCPT", reply: "550 5.1.1 no such user", exp: mailErrors.ErrPermanent},
+		{name: "message rejected", verb: "DATA", reply: "554 5.7.1 rejected", exp: mailErrors.ErrPermanent},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := smtptest.Start(t, smtptest.Config{})
+			server.SetReply(tc.verb, tc.reply)
+
+			provider, err := providers.NewSMTP(server.SMTPConfig())
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestSMTP_SendWithResult(t *testing.T) {
+	t.Parallel()
+
+	server := smtptest.Start(t, smtptest.Config{})
+
+	provider, err := providers.NewSMTP(server.SMTPConfig())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	res, err := provider.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	received := receivedData(server)
+	if !assert.Len(t, received, 1) {
+		t.FailNow()
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(received[0]))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.True(t, strings.HasSuffix(res.MessageID, "@spacetab.io"))
+	assert.Equal(t, "<"+res.MessageID+">", parsed.Header.Get("Message-ID"))
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", MessageID: res.MessageID, Status: "accepted"}}, res.Accepted)
+}
+
+func TestSMTP_SendWithAuth(t *testing.T) {
+	type testCase struct {
+		name     string
+		authType cfgstructs.AuthType
+		password string
+		isErr    bool
+	}
+
+	tcs := []testCase{
+		{name: "plain", authType: cfgstructs.AuthTypePlain, password: "secret"},
+		{name: "login", authType: cfgstructs.AuthTypeLogin, password: "secret"},
+		{name: "cram-md5", authType: cfgstructs.AuthTypeCRAMMD5, password: "secret"},
+		{name: "wrong password", authType: cfgstructs.AuthTypePlain, password: "wrong", isErr: true},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := smtptest.Start(t, smtptest.Config{Users: map[string]string{"user": "secret"}})
+
+			cfg := server.SMTPConfig()
+			cfg.AuthType = tc.authType
+			cfg.Username = "user"
+			cfg.Password = tc.password
+
+			provider, err := providers.NewSMTP(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			err = provider.Send(context.Background(), &msg)
+			if tc.isErr {
+				assert.ErrorIs(t, err, mailErrors.ErrPermanent)
+
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			envelopes := server.Envelopes()
+			if !assert.Len(t, envelopes, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, "user", envelopes[0].Username)
+		})
+	}
+}
+
+// remoteAddrs returns client addresses of received envelopes, one per smtp connection.
+func remoteAddrs(s *smtptest.Server) map[string]int {
+	addrs := make(map[string]int)
+	for _, e := range s.Envelopes() {
+		addrs[e.RemoteAddr]++
+	}
+
+	return addrs
+}
+
+func TestSMTP_SendReusesConnection(t *testing.T) {
+	t.Parallel()
+
+	server := smtptest.Start(t, smtptest.Config{})
+
+	provider, err := providers.NewSMTP(server.SMTPConfig())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	assert.NoError(t, provider.Send(context.Background(), &msg))
+
+	// rejected message doesn't break connection
+	server.SetReply("RCPT", "550 5.1.1 no such user")
+	assert.ErrorIs(t, provider.Send(context.Background(), &msg), mailErrors.ErrPermanent)
+	server.SetReply("RCPT", "")
+
+	assert.NoError(t, provider.Send(context.Background(), &msg))
+	assert.NoError(t, provider.Send(context.Background(), &msg))
+
+	addrs := remoteAddrs(server)
+	assert.Len(t, addrs, 1)
+
+	for _, n := range addrs {
+		assert.Equal(t, 3, n)
+	}
+}
+
+func TestSMTP_SendReconnects(t *testing.T) {
+	type testCase struct {
+		name      string
+		cfg       func(s *smtptest.Server) providers.SMTPConfig
+		interrupt func(s *smtptest.Server)
+	}
+
+	tcs := []testCase{
+		{
+			name:      "connection closed by server",
+			cfg:       func(s *smtptest.Server) providers.SMTPConfig { return providers.SMTPConfig{SMTPConfig: s.SMTPConfig()} },
+			interrupt: func(s *smtptest.Server) { s.CloseConnections() },
+		},
+		{
+			name: "idle timeout",
+			cfg: func(s *smtptest.Server) providers.SMTPConfig {
+				return providers.SMTPConfig{SMTPConfig: s.SMTPConfig(), IdleTimeout: 10 * time.Millisecond}
+			},
+			interrupt: func(s *smtptest.Server) { time.Sleep(20 * time.Millisecond) },
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := smtptest.Start(t, smtptest.Config{})
+
+			provider, err := providers.NewSMTP(tc.cfg(server))
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.NoError(t, provider.Send(context.Background(), &msg))
+			tc.interrupt(server)
+			assert.NoError(t, provider.Send(context.Background(), &msg))
+
+			assert.Len(t, remoteAddrs(server), 2)
+		})
+	}
+}
+
+func TestSMTP_SendDroppedConnection(t *testing.T) {
+	type testCase struct {
+		name    string
+		command string
+		// reject makes server reject message, so connection is reset before reuse
+		reject bool
+		exp    error
+	}
+
+	tcs := []testCase{
+		{name: "noop before reuse", command: "NOOP"},
+		{name: "rset after rejected message", command: "RSET", reject: true, exp: mailErrors.ErrPermanent},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := smtptest.Start(t, smtptest.Config{})
+
+			// server doesn't reply, as if it silently dropped connection; there is no send timeout
+			server.SetDelay(tc.command, time.Minute)
+
+			cfg := server.SMTPConfig()
+			cfg.ConnectionTimeout = 100 * time.Millisecond
+
+			provider, err := providers.NewSMTP(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+				t.FailNow()
+			}
+
+			if tc.reject {
+				server.SetReply("RCPT", "550 5.1.1 no such user")
+			}
+
+			start := time.Now()
+
+			err = provider.Send(context.Background(), &msg)
+			if tc.exp == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.exp)
+			}
+
+			assert.Less(t, time.Since(start), time.Second)
+		})
+	}
+}
+
+func TestSMTP_ClosesIdleConnections(t *testing.T) {
+	t.Parallel()
+
+	server := smtptest.Start(t, smtptest.Config{})
+
+	provider, err := providers.NewSMTP(providers.SMTPConfig{SMTPConfig: server.SMTPConfig(), IdleTimeout: 50 * time.Millisecond})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	if !assert.NoError(t, provider.Send(context.Background(), &msg)) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 1, server.OpenConnections())
+
+	// idle connection is closed without further sends
+	assert.Eventually(t, func() bool { return server.OpenConnections() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestSMTP_SendConcurrently(t *testing.T) {
+	t.Parallel()
+
+	const sends = 20
+
+	server := smtptest.Start(t, smtptest.Config{})
+
+	provider, err := providers.NewSMTP(providers.SMTPConfig{SMTPConfig: server.SMTPConfig(), MaxConnections: 2})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	errs := make(chan error, sends)
+
+	for i := 0; i < sends; i++ {
+		go func() {
+			msg := newTestMessage(nil)
+			errs <- provider.Send(context.Background(), &msg)
+		}()
+	}
+
+	for i := 0; i < sends; i++ {
+		assert.NoError(t, <-errs)
+	}
+
+	assert.Len(t, server.Envelopes(), sends)
+	assert.LessOrEqual(t, len(remoteAddrs(server)), 2)
+}
+
+func TestSMTP_LazyDial(t *testing.T) {
+	t.Parallel()
+
+	server := smtptest.Start(t, smtptest.Config{})
+	cfg := server.SMTPConfig()
+
+	// nothing listens on server port after close
+	assert.NoError(t, server.Close())
+
+	provider, err := providers.NewSMTP(cfg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	assert.ErrorIs(t, provider.Send(context.Background(), &msg), mailErrors.ErrTemporary)
+}
+
+func TestSMTP_Close(t *testing.T) {
+	t.Parallel()
+
+	server := smtptest.Start(t, smtptest.Config{})
+
+	provider, err := providers.NewSMTP(server.SMTPConfig())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	assert.NoError(t, provider.Send(context.Background(), &msg))
+	assert.NoError(t, provider.Close())
+	assert.ErrorIs(t, provider.Send(context.Background(), &msg), mailErrors.ErrProviderClosed)
+}
+
+func TestSMTP_SendCancel(t *testing.T) {
+	type testCase struct {
+		name    string
+		users   map[string]string
+		command string
+		cfg     func(cfg *mailing.SMTPConfig)
+		cancel  bool
+		exp     error
+	}
+
+	tcs := []testCase{
+		{name: "cancel on hello", command: "EHLO", cancel: true, exp: context.Canceled},
+		{
+			name:    "cancel on auth",
+			users:   map[string]string{"user": "secret"},
+			command: "AUTH",
+			cfg: func(cfg *mailing.SMTPConfig) {
+				cfg.AuthType, cfg.Username, cfg.Password = cfgstructs.AuthTypePlain, "user", "secret"
+			},
+			cancel: true,
+			exp:    context.Canceled,
+		},
+		{name: "cancel on data", command: ".", cancel: true, exp: context.Canceled},
+		{
+			name:    "connection timeout",
+			command: "EHLO",
+			cfg:     func(cfg *mailing.SMTPConfig) { cfg.ConnectionTimeout = 50 * time.Millisecond },
+			exp:     context.DeadlineExceeded,
+		},
+		{
+			name:    "send timeout",
+			command: ".",
+			cfg:     func(cfg *mailing.SMTPConfig) { cfg.SendTimeout = 50 * time.Millisecond },
+			exp:     context.DeadlineExceeded,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := smtptest.Start(t, smtptest.Config{Users: tc.users})
+			server.SetDelay(tc.command, time.Hour)
+
+			cfg := server.SMTPConfig()
+			if tc.cfg != nil {
+				tc.cfg(&cfg)
+			}
+
+			provider, err := providers.NewSMTP(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if tc.cancel {
+				time.AfterFunc(50*time.Millisecond, cancel)
+			}
+
+			msg := newTestMessage(nil)
+			start := time.Now()
+
+			assert.ErrorIs(t, provider.Send(ctx, &msg), tc.exp)
+			assert.Less(t, time.Since(start), time.Second)
+			assert.Empty(t, server.Envelopes())
+
+			// aborted connection is not reused
+			server.SetDelay(tc.command, 0)
+			assert.NoError(t, provider.Send(context.Background(), &msg))
+		})
+	}
+}
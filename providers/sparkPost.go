@@ -0,0 +1,258 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const sparkPostTransmissionsPath = "/transmissions"
+
+// SparkPostOptions are SparkPost settings of a single message.
+type SparkPostOptions struct {
+	// CampaignID overrides config campaign id.
+	CampaignID string
+	// TemplateID is an id of stored template used instead of message subject and body.
+	TemplateID string
+	// SubstitutionData are template substitutions of all recipients.
+	SubstitutionData map[string]interface{}
+	// RecipientSubstitutionData are template substitutions by recipient email.
+	RecipientSubstitutionData map[string]map[string]interface{}
+}
+
+type sparkPostOptionsKey struct{}
+
+// WithSparkPostOptions returns context carrying SparkPost settings of sent message.
+func WithSparkPostOptions(ctx context.Context, opts SparkPostOptions) context.Context {
+	return context.WithValue(ctx, sparkPostOptionsKey{}, opts)
+}
+
+// SparkPost sends messages with SparkPost transmissions api. Cc and bcc addresses are transmission
+// recipients too, they get message with original To header; cc ones are listed in Cc header.
+type SparkPost struct {
+	client      *http.Client
+	endpoint    string
+	providerCfg SparkPostConfigInterface
+}
+
+func NewSparkPost(providerCfg mailing.MailProviderConfigInterface) (SparkPost, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return SparkPost{}, fmt.Errorf("sparkpost provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(SparkPostConfigInterface)
+	if !ok {
+		return SparkPost{}, fmt.Errorf("sparkpost provider config error: %T is not SparkPostConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	return SparkPost{
+		client:      &http.Client{},
+		endpoint:    strings.TrimSuffix(cfg.GetHostPort().String(), "/"),
+		providerCfg: cfg,
+	}, nil
+}
+
+func (o SparkPost) Name() mailing.MailProviderName {
+	return "sparkPostAPI"
+}
+
+func (o SparkPost) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+type sparkPostAddress struct {
+	Email    string `json:"email"`
+	Name     string `json:"name,omitempty"`
+	HeaderTo string `json:"header_to,omitempty"`
+}
+
+type sparkPostRecipient struct {
+	Address          sparkPostAddress       `json:"address"`
+	SubstitutionData map[string]interface{} `json:"substitution_data,omitempty"`
+}
+
+type sparkPostAttachment struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+type sparkPostContent struct {
+	TemplateID   string                `json:"template_id,omitempty"`
+	From         *sparkPostAddress     `json:"from,omitempty"`
+	Subject      string                `json:"subject,omitempty"`
+	HTML         string                `json:"html,omitempty"`
+	Text         string                `json:"text,omitempty"`
+	ReplyTo      string                `json:"reply_to,omitempty"`
+	Headers      map[string]string     `json:"headers,omitempty"`
+	Attachments  []sparkPostAttachment `json:"attachments,omitempty"`
+	InlineImages []sparkPostAttachment `json:"inline_images,omitempty"`
+}
+
+type sparkPostOptions struct {
+	OpenTracking  bool `json:"open_tracking,omitempty"`
+	ClickTracking bool `json:"click_tracking,omitempty"`
+	Transactional bool `json:"transactional,omitempty"`
+}
+
+type sparkPostTransmission struct {
+	Options          *sparkPostOptions      `json:"options,omitempty"`
+	CampaignID       string                 `json:"campaign_id,omitempty"`
+	Metadata         map[string]string      `json:"metadata,omitempty"`
+	SubstitutionData map[string]interface{} `json:"substitution_data,omitempty"`
+	Recipients       []sparkPostRecipient   `json:"recipients"`
+	Content          sparkPostContent       `json:"content"`
+}
+
+type sparkPostResponse struct {
+	Results struct {
+		ID                      string `json:"id"`
+		TotalAcceptedRecipients int    `json:"total_accepted_recipients"`
+		TotalRejectedRecipients int    `json:"total_rejected_recipients"`
+	} `json:"results"`
+	Errors []struct {
+		Code        string `json:"code"`
+		Message     string `json:"message"`
+		Description string `json:"description"`
+	} `json:"errors"`
+}
+
+func (o SparkPost) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	req, _, err := newJSONRequest(ctx, o.endpoint+sparkPostTransmissionsPath, o.toSparkPostTransmission(ctx, msg))
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sparkpost request error: %w", err)
+	}
+
+	req.Header.Set("Authorization", o.providerCfg.GetPassword())
+
+	resp, body, err := doRequest(o.client, req)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sparkpost email send error: %w", err)
+	}
+
+	var spResp sparkPostResponse
+
+	_ = json.Unmarshal(body, &spResp)
+
+	if resp.StatusCode != http.StatusOK {
+		reason := ""
+		if len(spResp.Errors) != 0 {
+			reason = fmt.Sprintf("%s %s: %s", spResp.Errors[0].Code, spResp.Errors[0].Message, spResp.Errors[0].Description)
+		}
+
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("sparkpost send message error: %w", mailErrors.FromHTTPStatus(
+			resp.StatusCode,
+			fmt.Errorf("%d sparkpost error %s", resp.StatusCode, reason), // nolint: goerr113
+		))
+	}
+
+	if spResp.Results.TotalAcceptedRecipients == 0 && spResp.Results.TotalRejectedRecipients != 0 {
+		return newRejectedResult(o.Name(), "rejected by sparkpost", msg),
+			fmt.Errorf("sparkpost email send error: %w", mailErrors.Permanent(0, mailErrors.ErrAllRecipientsRejected))
+	}
+
+	return newSendResult(o.Name(), spResp.Results.ID, msg), nil
+}
+
+func (o SparkPost) toSparkPostTransmission(ctx context.Context, msg contracts.MessageInterface) sparkPostTransmission {
+	opts, _ := ctx.Value(sparkPostOptionsKey{}).(SparkPostOptions)
+
+	st := sparkPostTransmission{
+		CampaignID:       o.providerCfg.GetCampaignID(),
+		SubstitutionData: opts.SubstitutionData,
+	}
+
+	if opts.CampaignID != "" {
+		st.CampaignID = opts.CampaignID
+	}
+
+	if o.providerCfg.GetTrackOpens() || o.providerCfg.GetTrackClicks() || o.providerCfg.GetTransactional() {
+		st.Options = &sparkPostOptions{
+			OpenTracking:  o.providerCfg.GetTrackOpens(),
+			ClickTracking: o.providerCfg.GetTrackClicks(),
+			Transactional: o.providerCfg.GetTransactional(),
+		}
+	}
+
+	if metadata := messageTags(ctx, o.providerCfg.GetMetadata()); len(metadata) != 0 {
+		st.Metadata = metadata
+	}
+
+	headerTo := strings.Join(addressList(msg.GetTo()), ",")
+
+	for _, addr := range msg.GetTo().GetList() {
+		st.Recipients = append(st.Recipients, sparkPostRecipient{
+			Address:          sparkPostAddress{Email: addr.GetEmail(), Name: addr.GetName()},
+			SubstitutionData: opts.RecipientSubstitutionData[addr.GetEmail()],
+		})
+	}
+
+	for _, list := range []mailing.MailAddressListInterface{msg.GetCc(), msg.GetBcc()} {
+		for _, addr := range list.GetList() {
+			st.Recipients = append(st.Recipients, sparkPostRecipient{
+				Address:          sparkPostAddress{Email: addr.GetEmail(), Name: addr.GetName(), HeaderTo: headerTo},
+				SubstitutionData: opts.RecipientSubstitutionData[addr.GetEmail()],
+			})
+		}
+	}
+
+	if opts.TemplateID != "" {
+		st.Content = sparkPostContent{TemplateID: opts.TemplateID}
+
+		return st
+	}
+
+	st.Content = toSparkPostContent(msg)
+
+	return st
+}
+
+func toSparkPostContent(msg contracts.MessageInterface) sparkPostContent {
+	sc := sparkPostContent{Subject: msg.GetSubject()}
+
+	if !msg.GetFrom().IsEmpty() {
+		sc.From = &sparkPostAddress{Email: msg.GetFrom().GetEmail(), Name: msg.GetFrom().GetName()}
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		sc.ReplyTo = msg.GetReplyTo().String()
+	}
+
+	if cc := addressList(msg.GetCc()); len(cc) != 0 {
+		sc.Headers = map[string]string{"CC": strings.Join(cc, ",")}
+	}
+
+	switch {
+	case msg.IsAlternative():
+		sc.HTML, sc.Text = string(msg.GetHTML()), string(msg.GetPlainText())
+	case msg.GetMimeType() == mime.TextHTML:
+		sc.HTML = string(msg.GetBody())
+	default:
+		sc.Text = string(msg.GetBody())
+	}
+
+	for _, att := range msg.GetAttachments().GetList() {
+		sa := sparkPostAttachment{Name: contracts.AttachmentFileName(att), Type: att.GetMimeType(), Data: att.GetContent()}
+
+		if att.GetAttachMethod() == contracts.AttachMethodInline {
+			sc.InlineImages = append(sc.InlineImages, sa)
+		} else {
+			sc.Attachments = append(sc.Attachments, sa)
+		}
+	}
+
+	return sc
+}
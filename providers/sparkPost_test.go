@@ -0,0 +1,215 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+const sparkPostSuccess = `{"results":{"total_rejected_recipients":0,"total_accepted_recipients":1,"id":"11668787484950529"}}`
+
+func TestSparkPost_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		cfg  providers.SparkPostConfig
+		ctx  context.Context
+		in   func() contracts.Message
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			cfg:  providers.SparkPostConfig{APIKey: "key"},
+			ctx:  context.Background(),
+			in:   func() contracts.Message { return newTestMessage(nil) },
+			exp: `{
+				"recipients":[{"address":{"email":"to@spacetab.io","name":"To"}}],
+				"content":{"from":{"email":"from@spacetab.io","name":"From"},"subject":"Test email","html":"<p>test email content</p>"}
+			}`,
+		},
+		{
+			name: "inline and file attachments",
+			cfg:  providers.SparkPostConfig{APIKey: "key"},
+			ctx:  context.Background(),
+			in:   func() contracts.Message { return newTestMessage(testAttachments) },
+			exp: `{
+				"recipients":[{"address":{"email":"to@spacetab.io","name":"To"}}],
+				"content":{
+					"from":{"email":"from@spacetab.io","name":"From"},"subject":"Test email","html":"<p>test email content</p>",
+					"attachments":[{"name":"report.pdf","type":"application/pdf","data":"cGRm"}],
+					"inline_images":[{"name":"logo.png","type":"image/png","data":"cG5n"}]
+				}
+			}`,
+		},
+		{
+			name: "copies, campaign, tracking and metadata",
+			cfg: providers.SparkPostConfig{
+				APIKey:        "key",
+				CampaignID:    "default",
+				Metadata:      map[string]string{"app": "mails"},
+				TrackOpens:    true,
+				Transactional: true,
+			},
+			ctx: providers.WithTags(providers.WithSparkPostOptions(context.Background(), providers.SparkPostOptions{
+				CampaignID:                "welcome",
+				SubstitutionData:          map[string]interface{}{"company": "Spacetab"},
+				RecipientSubstitutionData: map[string]map[string]interface{}{"cc@spacetab.io": {"code": 42}},
+			}), map[string]string{"user_id": "7"}),
+			in: func() contracts.Message {
+				msg := newAlternativeTestMessage()
+				msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io", Name: "Cc"}}
+				msg.Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+				msg.ReplyTo = mailing.MailAddress{Email: "reply@spacetab.io"}
+
+				return msg
+			},
+			exp: `{
+				"options":{"open_tracking":true,"transactional":true},
+				"campaign_id":"welcome","metadata":{"app":"mails","user_id":"7"},"substitution_data":{"company":"Spacetab"},
+				"recipients":[
+					{"address":{"email":"to@spacetab.io","name":"To"}},
+					{"address":{"email":"cc@spacetab.io","name":"Cc","header_to":"\"To\" <to@spacetab.io>"},"substitution_data":{"code":42}},
+					{"address":{"email":"bcc@spacetab.io","header_to":"\"To\" <to@spacetab.io>"}}
+				],
+				"content":{
+					"from":{"email":"from@spacetab.io","name":"From"},"subject":"Test email",
+					"html":"<p>test email content</p>","text":"test email content",
+					"reply_to":"<reply@spacetab.io>","headers":{"CC":"\"Cc\" <cc@spacetab.io>"}
+				}
+			}`,
+		},
+		{
+			name: "stored template",
+			cfg:  providers.SparkPostConfig{APIKey: "key"},
+			ctx:  providers.WithSparkPostOptions(context.Background(), providers.SparkPostOptions{TemplateID: "order-shipped"}),
+			in:   func() contracts.Message { return newTestMessage(nil) },
+			exp: `{
+				"recipients":[{"address":{"email":"to@spacetab.io","name":"To"}}],
+				"content":{"template_id":"order-shipped"}
+			}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusOK, "application/json", sparkPostSuccess)
+
+			cfg := tc.cfg
+			cfg.Endpoint = api.server.URL + "/api/v1"
+
+			provider, err := providers.NewSparkPost(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in()
+
+			res, err := provider.SendWithResult(tc.ctx, &msg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, http.MethodPost, requests[0].method)
+			assert.Equal(t, "/api/v1/transmissions", requests[0].path)
+			assert.Equal(t, "key", requests[0].header.Get("Authorization"))
+			assert.JSONEq(t, tc.exp, string(requests[0].body))
+
+			assert.Equal(t, provider.Name(), res.Provider)
+			assert.Equal(t, "11668787484950529", res.MessageID)
+		})
+	}
+}
+
+func TestSparkPost_SendErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		status   int
+		response string
+		exp      error
+	}
+
+	tcs := []testCase{
+		{name: "invalid api key", status: http.StatusUnauthorized, response: `{"errors":[{"message":"Unauthorized."}]}`, exp: mailErrors.ErrPermanent},
+		{
+			name:     "unconfigured sending domain",
+			status:   http.StatusBadRequest,
+			response: `{"errors":[{"message":"Invalid domain","description":"Unconfigured Sending Domain <spacetab.io>","code":"7001"}]}`,
+			exp:      mailErrors.ErrPermanent,
+		},
+		{name: "rate limited", status: http.StatusTooManyRequests, response: `{"errors":[{"message":"Too many requests"}]}`, exp: mailErrors.ErrTemporary},
+		{name: "server error", status: http.StatusServiceUnavailable, response: ``, exp: mailErrors.ErrTemporary},
+		{
+			name:     "all recipients rejected",
+			status:   http.StatusOK,
+			response: `{"results":{"total_rejected_recipients":1,"total_accepted_recipients":0,"id":"1"}}`,
+			exp:      mailErrors.ErrAllRecipientsRejected,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "application/json", tc.response)
+
+			provider, err := providers.NewSparkPost(providers.SparkPostConfig{APIKey: "key", Endpoint: api.server.URL})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestSparkPostConfig_GetHostPort(t *testing.T) {
+	type testCase struct {
+		name string
+		in   providers.SparkPostConfig
+		exp  string
+	}
+
+	tcs := []testCase{
+		{name: "default", in: providers.SparkPostConfig{APIKey: "key"}, exp: "https://api.sparkpost.com/api/v1"},
+		{name: "eu", in: providers.SparkPostConfig{APIKey: "key", Region: providers.SparkPostRegionEU}, exp: "https://api.eu.sparkpost.com/api/v1"},
+		{name: "endpoint", in: providers.SparkPostConfig{APIKey: "key", Region: providers.SparkPostRegionEU, Endpoint: "http://localhost:8080"}, exp: "http://localhost:8080"},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tc.in.Validate()
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, tc.exp, tc.in.GetHostPort().String())
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package providers
+
+import "context"
+
+type tagsKey struct{}
+
+// WithTags returns context carrying message tags for providers supporting them.
+// Tags are merged with ones from provider config, context tags win.
+func WithTags(ctx context.Context, tags map[string]string) context.Context {
+	return context.WithValue(ctx, tagsKey{}, tags)
+}
+
+// messageTags returns config tags merged with context ones.
+func messageTags(ctx context.Context, cfgTags map[string]string) map[string]string {
+	tags := make(map[string]string, len(cfgTags))
+	for k, v := range cfgTags {
+		tags[k] = v
+	}
+
+	ctxTags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	for k, v := range ctxTags {
+		tags[k] = v
+	}
+
+	return tags
+}
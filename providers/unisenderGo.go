@@ -0,0 +1,279 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/configuration-structs-go/v2/mime"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const (
+	unisenderGoSendPath  = "/email/send.json"
+	unisenderGoKeyHeader = "X-API-KEY"
+	// unisenderGoToName is a recipient substitution with recipient name.
+	unisenderGoToName = "to_name"
+)
+
+// UnisenderGoOptions are Unisender Go settings of a single message.
+type UnisenderGoOptions struct {
+	// TemplateID is an id of template used instead of message subject and body.
+	TemplateID string
+	// GlobalSubstitutions are template substitutions of all recipients.
+	GlobalSubstitutions map[string]interface{}
+	// Substitutions are template substitutions by recipient email.
+	Substitutions map[string]map[string]interface{}
+}
+
+type unisenderGoOptionsKey struct{}
+
+// WithUnisenderGoOptions returns context carrying Unisender Go settings of sent message.
+func WithUnisenderGoOptions(ctx context.Context, opts UnisenderGoOptions) context.Context {
+	return context.WithValue(ctx, unisenderGoOptionsKey{}, opts)
+}
+
+// UnisenderGo sends messages with Unisender Go transactional api. Unisender Go sends a separate copy
+// of message to every recipient, so to, cc and bcc addresses are all message recipients; cc addresses
+// are also listed in CC header.
+type UnisenderGo struct {
+	client      *http.Client
+	endpoint    string
+	providerCfg UnisenderGoConfigInterface
+}
+
+func NewUnisenderGo(providerCfg mailing.MailProviderConfigInterface) (UnisenderGo, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return UnisenderGo{}, fmt.Errorf("unisender go provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(UnisenderGoConfigInterface)
+	if !ok {
+		return UnisenderGo{}, fmt.Errorf("unisender go provider config error: %T is not UnisenderGoConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	return UnisenderGo{
+		client:      &http.Client{},
+		endpoint:    strings.TrimSuffix(cfg.GetHostPort().String(), "/"),
+		providerCfg: cfg,
+	}, nil
+}
+
+func (o UnisenderGo) Name() mailing.MailProviderName {
+	return "unisenderGoAPI"
+}
+
+func (o UnisenderGo) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+type unisenderGoRecipient struct {
+	Email         string                 `json:"email"`
+	Substitutions map[string]interface{} `json:"substitutions,omitempty"`
+}
+
+type unisenderGoAttachment struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content []byte `json:"content"`
+}
+
+type unisenderGoBody struct {
+	HTML      string `json:"html,omitempty"`
+	PlainText string `json:"plaintext,omitempty"`
+}
+
+type unisenderGoMessage struct {
+	Recipients          []unisenderGoRecipient  `json:"recipients"`
+	TemplateID          string                  `json:"template_id,omitempty"`
+	Tags                []string                `json:"tags,omitempty"`
+	SkipUnsubscribe     int                     `json:"skip_unsubscribe"`
+	GlobalLanguage      string                  `json:"global_language,omitempty"`
+	TemplateEngine      string                  `json:"template_engine,omitempty"`
+	GlobalSubstitutions map[string]interface{}  `json:"global_substitutions,omitempty"`
+	GlobalMetadata      map[string]string       `json:"global_metadata,omitempty"`
+	Body                *unisenderGoBody        `json:"body,omitempty"`
+	Subject             string                  `json:"subject,omitempty"`
+	FromEmail           string                  `json:"from_email"`
+	FromName            string                  `json:"from_name,omitempty"`
+	ReplyTo             string                  `json:"reply_to,omitempty"`
+	Headers             map[string]string       `json:"headers,omitempty"`
+	TrackLinks          int                     `json:"track_links"`
+	TrackRead           int                     `json:"track_read"`
+	Attachments         []unisenderGoAttachment `json:"attachments,omitempty"`
+	InlineAttachments   []unisenderGoAttachment `json:"inline_attachments,omitempty"`
+}
+
+type unisenderGoRequest struct {
+	Message unisenderGoMessage `json:"message"`
+}
+
+type unisenderGoResponse struct {
+	Status       string            `json:"status"`
+	JobID        string            `json:"job_id"`
+	Emails       []string          `json:"emails"`
+	FailedEmails map[string]string `json:"failed_emails"`
+	Message      string            `json:"message"`
+	Code         int               `json:"code"`
+}
+
+func (o UnisenderGo) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	req, _, err := newJSONRequest(ctx, o.endpoint+unisenderGoSendPath, unisenderGoRequest{Message: o.toUnisenderGoMessage(ctx, msg)})
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("unisender go request error: %w", err)
+	}
+
+	req.Header.Set(unisenderGoKeyHeader, o.providerCfg.GetPassword())
+
+	resp, body, err := doRequest(o.client, req)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("unisender go email send error: %w", err)
+	}
+
+	var ugResp unisenderGoResponse
+
+	_ = json.Unmarshal(body, &ugResp)
+
+	if resp.StatusCode != http.StatusOK || ugResp.Status != "success" {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("unisender go send message error: %w", mailErrors.FromHTTPStatus(
+			resp.StatusCode,
+			fmt.Errorf("%d unisender go error %d: %s", resp.StatusCode, ugResp.Code, ugResp.Message), // nolint: goerr113
+		))
+	}
+
+	return toUnisenderGoResult(o.Name(), ugResp)
+}
+
+// toUnisenderGoResult maps accepted and failed emails into send result. Job id is result message id.
+func toUnisenderGoResult(name mailing.MailProviderName, resp unisenderGoResponse) (contracts.SendResult, error) {
+	res := contracts.SendResult{Provider: name, MessageID: resp.JobID, SentAt: time.Now()}
+
+	for _, email := range resp.Emails {
+		res.Accepted = append(res.Accepted, contracts.RecipientStatus{Email: email, MessageID: resp.JobID, Status: recipientStatusAccepted})
+	}
+
+	failed := make([]string, 0, len(resp.FailedEmails))
+	for email := range resp.FailedEmails {
+		failed = append(failed, email)
+	}
+
+	sort.Strings(failed)
+
+	for _, email := range failed {
+		res.Rejected = append(res.Rejected, contracts.RecipientStatus{Email: email, Status: recipientStatusRejected, Reason: resp.FailedEmails[email]})
+	}
+
+	if len(res.Accepted) == 0 && len(res.Rejected) != 0 {
+		return res, fmt.Errorf("unisender go email send error: %w", mailErrors.Permanent(0, mailErrors.ErrAllRecipientsRejected))
+	}
+
+	return res, nil
+}
+
+func (o UnisenderGo) toUnisenderGoMessage(ctx context.Context, msg contracts.MessageInterface) unisenderGoMessage {
+	opts, _ := ctx.Value(unisenderGoOptionsKey{}).(UnisenderGoOptions)
+
+	um := unisenderGoMessage{
+		TemplateID:          opts.TemplateID,
+		Tags:                o.providerCfg.GetTags(),
+		SkipUnsubscribe:     boolToInt(o.providerCfg.GetSkipUnsubscribe()),
+		GlobalLanguage:      o.providerCfg.GetGlobalLanguage(),
+		TemplateEngine:      o.providerCfg.GetTemplateEngine(),
+		GlobalSubstitutions: opts.GlobalSubstitutions,
+		Subject:             msg.GetSubject(),
+		FromEmail:           msg.GetFrom().GetEmail(),
+		FromName:            msg.GetFrom().GetName(),
+		TrackLinks:          boolToInt(o.providerCfg.GetTrackLinks()),
+		TrackRead:           boolToInt(o.providerCfg.GetTrackRead()),
+	}
+
+	if metadata := messageTags(ctx, nil); len(metadata) != 0 {
+		um.GlobalMetadata = metadata
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		um.ReplyTo = msg.GetReplyTo().GetEmail()
+	}
+
+	if !msg.GetCc().IsEmpty() {
+		um.Headers = map[string]string{"CC": unisenderGoAddressList(msg.GetCc())}
+	}
+
+	for _, list := range []mailing.MailAddressListInterface{msg.GetTo(), msg.GetCc(), msg.GetBcc()} {
+		for _, addr := range list.GetList() {
+			um.Recipients = append(um.Recipients, toUnisenderGoRecipient(addr, opts.Substitutions[addr.GetEmail()]))
+		}
+	}
+
+	switch {
+	case msg.IsAlternative():
+		um.Body = &unisenderGoBody{HTML: string(msg.GetHTML()), PlainText: string(msg.GetPlainText())}
+	case msg.GetMimeType() == mime.TextHTML:
+		um.Body = &unisenderGoBody{HTML: string(msg.GetBody())}
+	case len(msg.GetBody()) != 0:
+		um.Body = &unisenderGoBody{PlainText: string(msg.GetBody())}
+	}
+
+	for _, att := range msg.GetAttachments().GetList() {
+		ua := unisenderGoAttachment{Type: att.GetMimeType(), Name: contracts.AttachmentFileName(att), Content: att.GetContent()}
+
+		if att.GetAttachMethod() == contracts.AttachMethodInline {
+			um.InlineAttachments = append(um.InlineAttachments, ua)
+		} else {
+			um.Attachments = append(um.Attachments, ua)
+		}
+	}
+
+	return um
+}
+
+// toUnisenderGoRecipient returns recipient with its name as to_name substitution unless it is set explicitly.
+func toUnisenderGoRecipient(addr mailing.MailAddressInterface, substitutions map[string]interface{}) unisenderGoRecipient {
+	r := unisenderGoRecipient{Email: addr.GetEmail()}
+
+	if len(substitutions) == 0 && addr.GetName() == "" {
+		return r
+	}
+
+	r.Substitutions = make(map[string]interface{}, len(substitutions)+1)
+	if addr.GetName() != "" {
+		r.Substitutions[unisenderGoToName] = addr.GetName()
+	}
+
+	for k, v := range substitutions {
+		r.Substitutions[k] = v
+	}
+
+	return r
+}
+
+// unisenderGoAddressList returns addresses as header value.
+func unisenderGoAddressList(list mailing.MailAddressListInterface) string {
+	addrs := make([]string, 0, len(list.GetList()))
+	for _, addr := range list.GetList() {
+		addrs = append(addrs, (&mail.Address{Name: addr.GetName(), Address: addr.GetEmail()}).String())
+	}
+
+	return strings.Join(addrs, ", ")
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,217 @@
+package providers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+const unisenderGoSuccess = `{"status":"success","job_id":"1ZymBc-00041N-9X","emails":["to@spacetab.io"],"failed_emails":{}}`
+
+func TestUnisenderGo_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		cfg  providers.UnisenderGoConfig
+		ctx  context.Context
+		in   func() contracts.Message
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			cfg:  providers.UnisenderGoConfig{APIKey: "key"},
+			ctx:  context.Background(),
+			in:   func() contracts.Message { return newTestMessage(nil) },
+			exp: `{"message":{
+				"recipients":[{"email":"to@spacetab.io","substitutions":{"to_name":"To"}}],
+				"skip_unsubscribe":0,"track_links":0,"track_read":0,
+				"body":{"html":"<p>test email content</p>"},
+				"subject":"Test email","from_email":"from@spacetab.io","from_name":"From"
+			}}`,
+		},
+		{
+			name: "inline and file attachments",
+			cfg:  providers.UnisenderGoConfig{APIKey: "key"},
+			ctx:  context.Background(),
+			in:   func() contracts.Message { return newTestMessage(testAttachments) },
+			exp: `{"message":{
+				"recipients":[{"email":"to@spacetab.io","substitutions":{"to_name":"To"}}],
+				"skip_unsubscribe":0,"track_links":0,"track_read":0,
+				"body":{"html":"<p>test email content</p>"},
+				"subject":"Test email","from_email":"from@spacetab.io","from_name":"From",
+				"attachments":[{"type":"application/pdf","name":"report.pdf","content":"cGRm"}],
+				"inline_attachments":[{"type":"image/png","name":"logo.png","content":"cG5n"}]
+			}}`,
+		},
+		{
+			name: "template with substitutions, tracking and tags",
+			cfg: providers.UnisenderGoConfig{
+				APIKey:          "key",
+				TrackLinks:      true,
+				TrackRead:       true,
+				SkipUnsubscribe: true,
+				TemplateEngine:  "velocity",
+				GlobalLanguage:  "ru",
+				Tags:            []string{"welcome"},
+			},
+			ctx: providers.WithTags(providers.WithUnisenderGoOptions(context.Background(), providers.UnisenderGoOptions{
+				TemplateID:          "tpl-1",
+				GlobalSubstitutions: map[string]interface{}{"company": "Spacetab"},
+				Substitutions:       map[string]map[string]interface{}{"cc@spacetab.io": {"to_name": "Копия", "code": 42}},
+			}), map[string]string{"user_id": "7"}),
+			in: func() contracts.Message {
+				msg := newAlternativeTestMessage()
+				msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io", Name: "Cc"}}
+				msg.ReplyTo = mailing.MailAddress{Email: "reply@spacetab.io"}
+
+				return msg
+			},
+			exp: `{"message":{
+				"recipients":[
+					{"email":"to@spacetab.io","substitutions":{"to_name":"To"}},
+					{"email":"cc@spacetab.io","substitutions":{"to_name":"Копия","code":42}}
+				],
+				"template_id":"tpl-1","tags":["welcome"],"skip_unsubscribe":1,"global_language":"ru","template_engine":"velocity",
+				"global_substitutions":{"company":"Spacetab"},"global_metadata":{"user_id":"7"},
+				"track_links":1,"track_read":1,
+				"body":{"html":"<p>test email content</p>","plaintext":"test email content"},
+				"subject":"Test email","from_email":"from@spacetab.io","from_name":"From","reply_to":"reply@spacetab.io",
+				"headers":{"CC":"\"Cc\" <cc@spacetab.io>"}
+			}}`,
+		},
+		{
+			name: "cc and bcc recipients",
+			cfg:  providers.UnisenderGoConfig{APIKey: "key"},
+			ctx:  context.Background(),
+			in: func() contracts.Message {
+				msg := newTestMessage(nil)
+				msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io", Name: "Cc"}, {Email: "cc2@spacetab.io"}}
+				msg.Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+
+				return msg
+			},
+			exp: `{"message":{
+				"recipients":[
+					{"email":"to@spacetab.io","substitutions":{"to_name":"To"}},
+					{"email":"cc@spacetab.io","substitutions":{"to_name":"Cc"}},
+					{"email":"cc2@spacetab.io"},
+					{"email":"bcc@spacetab.io"}
+				],
+				"skip_unsubscribe":0,"track_links":0,"track_read":0,
+				"body":{"html":"<p>test email content</p>"},
+				"subject":"Test email","from_email":"from@spacetab.io","from_name":"From",
+				"headers":{"CC":"\"Cc\" <cc@spacetab.io>, <cc2@spacetab.io>"}
+			}}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusOK, "application/json", unisenderGoSuccess)
+
+			cfg := tc.cfg
+			cfg.Endpoint = api.server.URL + "/ru/transactional/api/v1"
+
+			provider, err := providers.NewUnisenderGo(cfg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in()
+			if !assert.NoError(t, provider.Send(tc.ctx, &msg)) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, http.MethodPost, requests[0].method)
+			assert.Equal(t, "/ru/transactional/api/v1/email/send.json", requests[0].path)
+			assert.Equal(t, "key", requests[0].header.Get("X-API-KEY"))
+			assert.JSONEq(t, tc.exp, string(requests[0].body))
+		})
+	}
+}
+
+func TestUnisenderGo_SendErrors(t *testing.T) {
+	type testCase struct {
+		name     string
+		status   int
+		response string
+		exp      error
+	}
+
+	tcs := []testCase{
+		{name: "invalid api key", status: http.StatusUnauthorized, response: `{"status":"error","message":"User with id 1 not found","code":114}`, exp: mailErrors.ErrPermanent},
+		{name: "bad request", status: http.StatusBadRequest, response: `{"status":"error","message":"Invalid from_email","code":204}`, exp: mailErrors.ErrPermanent},
+		{name: "rate limited", status: http.StatusTooManyRequests, response: `{"status":"error","message":"Too many requests","code":1001}`, exp: mailErrors.ErrTemporary},
+		{name: "server error", status: http.StatusInternalServerError, response: ``, exp: mailErrors.ErrTemporary},
+		{
+			name:     "all recipients failed",
+			status:   http.StatusOK,
+			response: `{"status":"success","job_id":"1ZymBc","emails":[],"failed_emails":{"to@spacetab.io":"unsubscribed"}}`,
+			exp:      mailErrors.ErrAllRecipientsRejected,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "application/json", tc.response)
+
+			provider, err := providers.NewUnisenderGo(providers.UnisenderGoConfig{APIKey: "key", Endpoint: api.server.URL})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestUnisenderGo_SendWithResult(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusOK, "application/json", `{
+		"status":"success","job_id":"1ZymBc-00041N-9X",
+		"emails":["to@spacetab.io"],"failed_emails":{"cc@spacetab.io":"temporary_unavailable"}
+	}`)
+
+	provider, err := providers.NewUnisenderGo(providers.UnisenderGoConfig{APIKey: "key", Endpoint: api.server.URL})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+	msg.Cc = mailing.MailAddressList{{Email: "cc@spacetab.io"}}
+
+	res, err := provider.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, provider.Name(), res.Provider)
+	assert.Equal(t, "1ZymBc-00041N-9X", res.MessageID)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "to@spacetab.io", MessageID: "1ZymBc-00041N-9X", Status: "accepted"}}, res.Accepted)
+	assert.Equal(t, []contracts.RecipientStatus{{Email: "cc@spacetab.io", Status: "rejected", Reason: "temporary_unavailable"}}, res.Rejected)
+}
@@ -0,0 +1,219 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+)
+
+const (
+	WebhookMessageIDHeader = "X-Mails-Message-Id"
+	WebhookTimestampHeader = "X-Mails-Timestamp"
+	WebhookSignatureHeader = "X-Mails-Signature"
+)
+
+// Webhook posts messages to configured url as json or raw MIME, so other services can send them.
+// Message is accepted on 2xx response; 408, 429 and 5xx responses are temporary errors, other ones are permanent.
+type Webhook struct {
+	client      *http.Client
+	providerCfg WebhookConfigInterface
+}
+
+func NewWebhook(providerCfg mailing.MailProviderConfigInterface) (Webhook, error) {
+	if _, err := providerCfg.Validate(); err != nil {
+		return Webhook{}, fmt.Errorf("webhook provider config validation error: %w", err)
+	}
+
+	cfg, ok := providerCfg.(WebhookConfigInterface)
+	if !ok {
+		return Webhook{}, fmt.Errorf("webhook provider config error: %T is not WebhookConfigInterface", providerCfg) // nolint: goerr113
+	}
+
+	return Webhook{client: &http.Client{}, providerCfg: cfg}, nil
+}
+
+func (o Webhook) Name() mailing.MailProviderName {
+	return MailProviderWebhook
+}
+
+func (o Webhook) Send(ctx context.Context, msg contracts.MessageInterface) error {
+	_, err := o.SendWithResult(ctx, msg)
+
+	return err
+}
+
+// WebhookAddress is an address of webhook json message.
+type WebhookAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// WebhookAttachment is an attachment of webhook json message, content is base64 encoded.
+type WebhookAttachment struct {
+	Filename string `json:"filename"`
+	MimeType string `json:"mimeType"`
+	Inline   bool   `json:"inline,omitempty"`
+	Content  []byte `json:"content"`
+}
+
+// WebhookMessage is a webhook json request body.
+type WebhookMessage struct {
+	MessageID   string              `json:"messageId"`
+	From        *WebhookAddress     `json:"from,omitempty"`
+	ReplyTo     *WebhookAddress     `json:"replyTo,omitempty"`
+	To          []WebhookAddress    `json:"to"`
+	Cc          []WebhookAddress    `json:"cc,omitempty"`
+	Bcc         []WebhookAddress    `json:"bcc,omitempty"`
+	Subject     string              `json:"subject"`
+	MimeType    string              `json:"mimeType,omitempty"`
+	Body        string              `json:"body,omitempty"`
+	HTML        string              `json:"html,omitempty"`
+	PlainText   string              `json:"plainText,omitempty"`
+	Attachments []WebhookAttachment `json:"attachments,omitempty"`
+}
+
+type webhookResponse struct {
+	MessageID string `json:"messageId"`
+}
+
+func (o Webhook) SendWithResult(ctx context.Context, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	messageID := eml.NewMessageID(msg.GetFrom().GetDomain())
+
+	body, contentType, err := o.encode(msg, messageID)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("webhook message build error: %w", mailErrors.Permanent(0, err))
+	}
+
+	ctx, cancel := withSendTimeout(ctx, o.providerCfg.GetSendTimeout())
+
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.providerCfg.GetURL(), bytes.NewReader(body))
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("webhook request build error: %w", mailErrors.Permanent(0, err))
+	}
+
+	for k, v := range o.providerCfg.GetHeaders() {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(WebhookMessageIDHeader, messageID)
+
+	if secret := o.providerCfg.GetSecret(); secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, WebhookSignature(secret, timestamp, body))
+	}
+
+	resp, respBody, err := doRequest(o.client, req)
+	if err != nil {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("webhook send error: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return contracts.SendResult{Provider: o.Name()}, fmt.Errorf("webhook send message error: %w", mailErrors.FromHTTPStatus(
+			resp.StatusCode,
+			fmt.Errorf("%d %s", resp.StatusCode, bytes.TrimSpace(respBody)), // nolint: goerr113
+		))
+	}
+
+	// receiver may reply with its own message id
+	var whResp webhookResponse
+	if err := json.Unmarshal(respBody, &whResp); err == nil && whResp.MessageID != "" {
+		messageID = whResp.MessageID
+	}
+
+	return newSendResult(o.Name(), messageID, msg), nil
+}
+
+func (o Webhook) encode(msg contracts.MessageInterface, messageID string) ([]byte, string, error) {
+	if o.providerCfg.GetFormat() == WebhookFormatMIME {
+		// receiver gets envelope recipients from headers, so bcc ones are kept
+		body, err := eml.Marshal(msg, eml.Options{MessageID: messageID, IncludeBcc: true})
+		if err != nil {
+			return nil, "", fmt.Errorf("mime encode error: %w", err)
+		}
+
+		return body, "message/rfc822", nil
+	}
+
+	body, err := json.Marshal(toWebhookMessage(msg, messageID))
+	if err != nil {
+		return nil, "", fmt.Errorf("json encode error: %w", err)
+	}
+
+	return body, "application/json", nil
+}
+
+func toWebhookMessage(msg contracts.MessageInterface, messageID string) WebhookMessage {
+	wm := WebhookMessage{
+		MessageID: messageID,
+		To:        toWebhookAddresses(msg.GetTo()),
+		Cc:        toWebhookAddresses(msg.GetCc()),
+		Bcc:       toWebhookAddresses(msg.GetBcc()),
+		Subject:   msg.GetSubject(),
+	}
+
+	if !msg.GetFrom().IsEmpty() {
+		wm.From = &WebhookAddress{Email: msg.GetFrom().GetEmail(), Name: msg.GetFrom().GetName()}
+	}
+
+	if !msg.GetReplyTo().IsEmpty() {
+		wm.ReplyTo = &WebhookAddress{Email: msg.GetReplyTo().GetEmail(), Name: msg.GetReplyTo().GetName()}
+	}
+
+	if msg.IsAlternative() {
+		wm.HTML, wm.PlainText = string(msg.GetHTML()), string(msg.GetPlainText())
+	} else {
+		wm.MimeType, wm.Body = msg.GetMimeType().String(), string(msg.GetBody())
+	}
+
+	for _, att := range msg.GetAttachments().GetList() {
+		wm.Attachments = append(wm.Attachments, WebhookAttachment{
+			Filename: contracts.AttachmentFileName(att),
+			MimeType: att.GetMimeType(),
+			Inline:   att.GetAttachMethod() == contracts.AttachMethodInline,
+			Content:  att.GetContent(),
+		})
+	}
+
+	return wm
+}
+
+func toWebhookAddresses(list mailing.MailAddressListInterface) []WebhookAddress {
+	if list == nil || list.IsEmpty() {
+		return nil
+	}
+
+	addrs := make([]WebhookAddress, 0, len(list.GetList()))
+	for _, addr := range list.GetList() {
+		addrs = append(addrs, WebhookAddress{Email: addr.GetEmail(), Name: addr.GetName()})
+	}
+
+	return addrs
+}
+
+// WebhookSignature returns webhook request signature "sha256=<hex>", which is HMAC-SHA256 of
+// timestamp header value, dot and request body keyed with secret. Receivers compare it with
+// X-Mails-Signature header using hmac.Equal and reject stale timestamps.
+func WebhookSignature(secret string, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	_, _ = h.Write([]byte(timestamp + "."))
+	_, _ = h.Write(body)
+
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,239 @@
+package providers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/spacetab-io/mails-go/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Send(t *testing.T) {
+	type testCase struct {
+		name string
+		in   contracts.Message
+		exp  string
+	}
+
+	tcs := []testCase{
+		{
+			name: "no attachments",
+			in:   newTestMessage(nil),
+			exp: `{
+				"messageId":"%s","from":{"email":"from@spacetab.io","name":"From"},"to":[{"email":"to@spacetab.io","name":"To"}],
+				"subject":"Test email","mimeType":"text/html","body":"<p>test email content</p>"
+			}`,
+		},
+		{
+			name: "inline and file attachments",
+			in:   newTestMessage(testAttachments),
+			exp: `{
+				"messageId":"%s","from":{"email":"from@spacetab.io","name":"From"},"to":[{"email":"to@spacetab.io","name":"To"}],
+				"subject":"Test email","mimeType":"text/html","body":"<p>test email content</p>",
+				"attachments":[
+					{"filename":"logo.png","mimeType":"image/png","inline":true,"content":"cG5n"},
+					{"filename":"report.pdf","mimeType":"application/pdf","content":"cGRm"}
+				]
+			}`,
+		},
+		{
+			name: "html and plain text",
+			in: func() contracts.Message {
+				msg := newAlternativeTestMessage()
+				msg.Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+				msg.ReplyTo = mailing.MailAddress{Email: "reply@spacetab.io"}
+
+				return msg
+			}(),
+			exp: `{
+				"messageId":"%s","from":{"email":"from@spacetab.io","name":"From"},"replyTo":{"email":"reply@spacetab.io"},
+				"to":[{"email":"to@spacetab.io","name":"To"}],"bcc":[{"email":"bcc@spacetab.io"}],
+				"subject":"Test email","html":"<p>test email content</p>","plainText":"test email content"
+			}`,
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, http.StatusAccepted, "application/json", "")
+
+			provider, err := providers.NewWebhook(providers.WebhookConfig{
+				URL:     api.server.URL + "/hooks/mail",
+				Secret:  "secret",
+				Headers: map[string]string{"Authorization": "Bearer token"},
+			})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := tc.in
+
+			res, err := provider.SendWithResult(context.Background(), &msg)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			requests := api.received()
+			if !assert.Len(t, requests, 1) {
+				t.FailNow()
+			}
+
+			r := requests[0]
+
+			assert.Equal(t, http.MethodPost, r.method)
+			assert.Equal(t, "/hooks/mail", r.path)
+			assert.Equal(t, "application/json", r.header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", r.header.Get("Authorization"))
+			assert.Equal(t, res.MessageID, r.header.Get(providers.WebhookMessageIDHeader))
+			assert.JSONEq(t, fmt.Sprintf(tc.exp, res.MessageID), string(r.body))
+
+			timestamp, err := strconv.ParseInt(r.header.Get(providers.WebhookTimestampHeader), 10, 64)
+			assert.NoError(t, err)
+			assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+			assert.Equal(t, providers.WebhookSignature("secret", r.header.Get(providers.WebhookTimestampHeader), r.body), r.header.Get(providers.WebhookSignatureHeader))
+		})
+	}
+}
+
+func TestWebhook_SendMIME(t *testing.T) {
+	t.Parallel()
+
+	api := newAPIStandIn(t, http.StatusOK, "application/json", `{"messageId":"receiver-123"}`)
+
+	provider, err := providers.NewWebhook(providers.WebhookConfig{URL: api.server.URL, Format: providers.WebhookFormatMIME})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(testAttachments)
+	msg.Bcc = mailing.MailAddressList{{Email: "bcc@spacetab.io"}}
+
+	res, err := provider.SendWithResult(context.Background(), &msg)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	requests := api.received()
+	if !assert.Len(t, requests, 1) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "message/rfc822", requests[0].header.Get("Content-Type"))
+	assert.Empty(t, requests[0].header.Get(providers.WebhookSignatureHeader))
+
+	parsed, err := eml.Unmarshal(requests[0].body)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "Test email", parsed.GetSubject())
+	assert.Equal(t, mailing.MailAddressList{{Email: "bcc@spacetab.io"}}, parsed.Bcc)
+	assert.Len(t, parsed.GetAttachments().GetList(), 2)
+
+	assert.Equal(t, "receiver-123", res.MessageID)
+	assert.Equal(t, "receiver-123", res.Accepted[0].MessageID)
+}
+
+func TestWebhook_SendErrors(t *testing.T) {
+	type testCase struct {
+		name   string
+		status int
+		exp    error
+	}
+
+	tcs := []testCase{
+		{name: "bad request", status: http.StatusBadRequest, exp: mailErrors.ErrPermanent},
+		{name: "not found", status: http.StatusNotFound, exp: mailErrors.ErrPermanent},
+		{name: "redirect", status: http.StatusNotModified, exp: mailErrors.ErrPermanent},
+		{name: "too many requests", status: http.StatusTooManyRequests, exp: mailErrors.ErrTemporary},
+		{name: "bad gateway", status: http.StatusBadGateway, exp: mailErrors.ErrTemporary},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newAPIStandIn(t, tc.status, "text/plain", "nope")
+
+			provider, err := providers.NewWebhook(providers.WebhookConfig{URL: api.server.URL})
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			msg := newTestMessage(nil)
+
+			assert.ErrorIs(t, provider.Send(context.Background(), &msg), tc.exp)
+		})
+	}
+}
+
+func TestWebhook_SendTimeout(t *testing.T) {
+	t.Parallel()
+
+	blocked := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-blocked:
+		case <-r.Context().Done():
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	provider, err := providers.NewWebhook(providers.WebhookConfig{URL: server.URL, SendTimeout: 50 * time.Millisecond})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := newTestMessage(nil)
+
+	assert.ErrorIs(t, provider.Send(context.Background(), &msg), context.DeadlineExceeded)
+	close(blocked)
+}
+
+func TestNewWebhook(t *testing.T) {
+	type testCase struct {
+		name  string
+		in    providers.WebhookConfig
+		isErr bool
+	}
+
+	tcs := []testCase{
+		{name: "valid", in: providers.WebhookConfig{URL: "https://hooks.spacetab.io/mail", Format: providers.WebhookFormatMIME}},
+		{name: "no url", in: providers.WebhookConfig{}, isErr: true},
+		{name: "unknown format", in: providers.WebhookConfig{URL: "https://hooks.spacetab.io/mail", Format: "xml"}, isErr: true},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := providers.NewWebhook(tc.in)
+			if tc.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package mails
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/errors"
+)
+
+const defaultBackoffMultiplier = 2
+
+// RetryPolicy configures retries of provider errors in Mailing.Send.
+type RetryPolicy struct {
+	// MaxAttempts is a total number of send attempts. Zero or one means no retries.
+	MaxAttempts int
+	// InitialBackoff is a delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff limits delay between attempts. Zero means no limit.
+	MaxBackoff time.Duration
+	// Multiplier is a backoff growth factor. Default is 2.
+	Multiplier float64
+	// Jitter randomizes every backoff by ±Jitter fraction of it, from 0 to 1.
+	Jitter float64
+	// MaxElapsedTime limits total time spent on attempts and backoffs. Zero means no limit.
+	MaxElapsedTime time.Duration
+	// IsRetryable classifies provider errors. Default is errors.IsTemporary, so permanent
+	// rejections and not classified errors are never retried.
+	IsRetryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns policy with 3 attempts and exponential backoff from 500ms to 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,                      // nolint: gomnd
+		InitialBackoff: 500 * time.Millisecond, // nolint: gomnd
+		MaxBackoff:     10 * time.Second,       // nolint: gomnd
+		Multiplier:     defaultBackoffMultiplier,
+		Jitter:         0.2,              // nolint: gomnd
+		MaxElapsedTime: 30 * time.Second, // nolint: gomnd
+	}
+}
+
+// Backoff returns delay before retry after attempt number attempt (starting from 1).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1) // nolint: gosec, gomnd
+	}
+
+	return time.Duration(backoff)
+}
+
+func (p RetryPolicy) isRetryable(err error) bool {
+	if p.IsRetryable != nil {
+		return p.IsRetryable(err)
+	}
+
+	return errors.IsTemporary(err)
+}
+
+// send calls provider until it succeeds, returns not retryable error or attempts are exhausted.
+// Retry is not started if its backoff ends after ctx deadline or max elapsed time.
+func (p RetryPolicy) send(ctx context.Context, provider contracts.ProviderInterface, msg contracts.MessageInterface) (contracts.SendResult, error) {
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		res, err := contracts.SendWithResult(ctx, provider, msg)
+		if err == nil {
+			return res, nil
+		}
+
+		if attempt >= p.MaxAttempts || !p.isRetryable(err) || ctx.Err() != nil {
+			return res, err
+		}
+
+		backoff := p.Backoff(attempt)
+		next := time.Now().Add(backoff)
+
+		if p.MaxElapsedTime > 0 && next.Sub(start) > p.MaxElapsedTime {
+			return res, err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && next.After(deadline) {
+			return res, err
+		}
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return res, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package mails_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go"
+	"github.com/spacetab-io/mails-go/contracts"
+	mailErrors "github.com/spacetab-io/mails-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyProvider returns configured errors one by one, then succeeds.
+type flakyProvider struct {
+	mu    sync.Mutex
+	errs  []error
+	calls int
+}
+
+func (p *flakyProvider) Name() mailing.MailProviderName {
+	return "flaky"
+}
+
+func (p *flakyProvider) Send(_ context.Context, _ contracts.MessageInterface) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+
+	if len(p.errs) == 0 {
+		return nil
+	}
+
+	err := p.errs[0]
+	p.errs = p.errs[1:]
+
+	return err
+}
+
+func (p *flakyProvider) sendCalls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.calls
+}
+
+func TestMailing_SendRetry(t *testing.T) {
+	errTemporary := mailErrors.Temporary(451, errors.New("try again later"))     // nolint: goerr113
+	errPermanent := mailErrors.Permanent(550, errors.New("mailbox unavailable")) // nolint: goerr113
+	errUnknown := errors.New("unknown")                                          // nolint: goerr113
+	policy := mails.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2, Jitter: 0.5}
+
+	type inStruct struct {
+		errs    []error
+		policy  mails.RetryPolicy
+		timeout time.Duration
+	}
+	type expStruct struct {
+		calls int
+		err   error
+	}
+	type testCase struct {
+		name string
+		in   inStruct
+		exp  expStruct
+	}
+
+	tcs := []testCase{
+		{
+			name: "no retry policy",
+			in:   inStruct{errs: []error{errTemporary}},
+			exp:  expStruct{calls: 1, err: errTemporary},
+		},
+		{
+			name: "temporary errors retried",
+			in:   inStruct{errs: []error{errTemporary, errTemporary}, policy: policy},
+			exp:  expStruct{calls: 3},
+		},
+		{
+			name: "attempts exhausted",
+			in:   inStruct{errs: []error{errTemporary, errTemporary, errTemporary, errTemporary}, policy: policy},
+			exp:  expStruct{calls: 3, err: mailErrors.ErrTemporary},
+		},
+		{
+			name: "permanent error is not retried",
+			in:   inStruct{errs: []error{errPermanent}, policy: policy},
+			exp:  expStruct{calls: 1, err: mailErrors.ErrPermanent},
+		},
+		{
+			name: "not classified error is not retried",
+			in:   inStruct{errs: []error{errUnknown}, policy: policy},
+			exp:  expStruct{calls: 1, err: errUnknown},
+		},
+		{
+			name: "custom classifier",
+			in: inStruct{errs: []error{errUnknown}, policy: mails.RetryPolicy{
+				MaxAttempts: 2,
+				IsRetryable: func(err error) bool { return errors.Is(err, errUnknown) },
+			}},
+			exp: expStruct{calls: 2},
+		},
+		{
+			name: "backoff after context deadline",
+			in: inStruct{
+				errs:    []error{errTemporary, errTemporary},
+				policy:  mails.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour},
+				timeout: time.Minute,
+			},
+			exp: expStruct{calls: 1, err: mailErrors.ErrTemporary},
+		},
+		{
+			name: "max elapsed time",
+			in: inStruct{
+				errs:   []error{errTemporary, errTemporary},
+				policy: mails.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute, MaxElapsedTime: time.Second},
+			},
+			exp: expStruct{calls: 1, err: mailErrors.ErrTemporary},
+		},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			if tc.in.timeout != 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tc.in.timeout)
+				defer cancel()
+			}
+
+			provider := &flakyProvider{errs: tc.in.errs}
+			m := mails.NewMailingForProvider(provider, mailing.MessagingConfig{}).WithRetryPolicy(tc.in.policy)
+			msg := contracts.Message{Subject: "Test email"}
+
+			err := m.Send(ctx, &msg)
+			if tc.exp.err != nil {
+				assert.ErrorIs(t, err, tc.exp.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.exp.calls, provider.sendCalls())
+		})
+	}
+}
+
+func TestMailing_SendRetryCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	provider := &flakyProvider{errs: []error{mailErrors.Temporary(0, errors.New("timeout"))}} // nolint: goerr113
+	m := mails.NewMailingForProvider(provider, mailing.MessagingConfig{}).
+		WithRetryPolicy(mails.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour})
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	msg := contracts.Message{Subject: "Test email"}
+
+	assert.ErrorIs(t, m.Send(ctx, &msg), mailErrors.ErrTemporary)
+	assert.Equal(t, 1, provider.sendCalls())
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	p := mails.RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+
+	assert.Equal(t, 100*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 300*time.Millisecond, p.Backoff(2))
+	assert.Equal(t, 900*time.Millisecond, p.Backoff(3))
+	assert.Equal(t, time.Second, p.Backoff(4))
+
+	p.Jitter = 0.5
+
+	for i := 0; i < 100; i++ {
+		b := p.Backoff(1)
+		assert.True(t, b >= 50*time.Millisecond && b <= 150*time.Millisecond, b)
+	}
+}
@@ -0,0 +1,368 @@
+// Package smtptest provides an in-process smtp server for tests and local development.
+// Server supports EHLO, AUTH PLAIN/LOGIN/CRAM-MD5 and STARTTLS, stores received envelopes
+// and replies with configured codes or delays to inject failures.
+package smtptest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cfgstructs "github.com/spacetab-io/configuration-structs-go/v2/contracts"
+	"github.com/spacetab-io/configuration-structs-go/v2/mailing"
+	"github.com/spacetab-io/mails-go/contracts"
+	"github.com/spacetab-io/mails-go/eml"
+)
+
+const (
+	defaultAddr     = "127.0.0.1:0"
+	defaultHostname = "localhost"
+	defaultTimeout  = 5 * time.Second
+)
+
+// Config configures smtp server.
+type Config struct {
+	// Addr is listen address, random loopback port by default.
+	Addr string
+	// Hostname is server name used in greeting, EHLO reply and generated certificate.
+	Hostname string
+	// Users are accepted AUTH usernames with passwords. AUTH is required before MAIL if Users are set.
+	Users map[string]string
+	// TLSConfig is STARTTLS config. Self-signed certificate for Hostname and loopback addresses is used if nil.
+	TLSConfig *tls.Config
+	// DisableSTARTTLS stops advertising STARTTLS extension.
+	DisableSTARTTLS bool
+	// RequireTLS rejects AUTH and MAIL commands before STARTTLS.
+	RequireTLS bool
+	// MaxSize is message size limit in bytes, unlimited if zero.
+	MaxSize int
+	// OnReceive is called with every stored envelope, e.g. to deliver it into dev inbox.
+	OnReceive func(Envelope)
+}
+
+// Envelope is a message received by server.
+type Envelope struct {
+	RemoteAddr string
+	Helo       string
+	// Username is authenticated user, empty if client didn't authenticate.
+	Username string
+	TLS      bool
+	From     string
+	To       []string
+	// Data is raw message as sent after DATA command, with dot-stuffing removed.
+	Data       []byte
+	ReceivedAt time.Time
+}
+
+// Message parses envelope data into message.
+func (e Envelope) Message() (contracts.Message, error) {
+	return eml.Unmarshal(e.Data)
+}
+
+func (e Envelope) clone() Envelope {
+	e.To = append([]string(nil), e.To...)
+	e.Data = append([]byte(nil), e.Data...)
+
+	return e
+}
+
+// Server is an smtp server listening for connections until Close is called.
+type Server struct {
+	cfg       Config
+	listener  net.Listener
+	tlsConfig *tls.Config
+	// certPool contains generated certificate, it is nil for Config.TLSConfig.
+	certPool *x509.CertPool
+
+	mu        sync.Mutex
+	envelopes []Envelope
+	replies   map[string]string
+	delays    map[string]time.Duration
+	conns     map[net.Conn]struct{}
+	// changed is closed and replaced on every stored envelope.
+	changed chan struct{}
+	// closed is closed by Close to interrupt delayed replies.
+	closed    chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewServer starts smtp server.
+func NewServer(cfg Config) (*Server, error) {
+	if cfg.Addr == "" {
+		cfg.Addr = defaultAddr
+	}
+
+	if cfg.Hostname == "" {
+		cfg.Hostname = defaultHostname
+	}
+
+	s := &Server{
+		cfg:       cfg,
+		tlsConfig: cfg.TLSConfig,
+		replies:   make(map[string]string),
+		delays:    make(map[string]time.Duration),
+		conns:     make(map[net.Conn]struct{}),
+		changed:   make(chan struct{}),
+		closed:    make(chan struct{}),
+	}
+
+	if s.tlsConfig == nil && !cfg.DisableSTARTTLS {
+		cert, pool, err := newSelfSignedCert(cfg.Hostname)
+		if err != nil {
+			return nil, fmt.Errorf("smtptest server certificate error: %w", err)
+		}
+
+		s.tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+		s.certPool = pool
+	}
+
+	l, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("smtptest server listen error: %w", err)
+	}
+
+	s.listener = l
+
+	s.wg.Add(1)
+
+	go s.serve()
+
+	return s, nil
+}
+
+// Start starts smtp server for test t and closes it on test cleanup.
+func Start(t testing.TB, cfg Config) *Server {
+	t.Helper()
+
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("smtptest server start error: %s", err)
+	}
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+// Addr returns server listen address.
+func (s *Server) Addr() *net.TCPAddr {
+	return s.listener.Addr().(*net.TCPAddr) // nolint: forcetypeassert
+}
+
+// SMTPConfig returns smtp provider config for server without encryption and authentication.
+func (s *Server) SMTPConfig() mailing.SMTPConfig {
+	return mailing.SMTPConfig{
+		Host:              s.Addr().IP.String(),
+		Port:              uint(s.Addr().Port),
+		Encryption:        mailing.MailProviderEncryptionNone,
+		AuthType:          cfgstructs.AuthTypeNone,
+		ConnectionTimeout: defaultTimeout,
+		SendTimeout:       defaultTimeout,
+	}
+}
+
+// ClientTLSConfig returns client tls config trusting generated server certificate.
+func (s *Server) ClientTLSConfig() *tls.Config {
+	return &tls.Config{RootCAs: s.certPool, ServerName: s.cfg.Hostname, MinVersion: tls.VersionTLS12}
+}
+
+// SetReply makes server reply with reply to commands starting with command (case insensitive)
+// without processing them, e.g. "RCPT TO:<bad@example.com>" or "DATA". Command "." sets reply
+// to the end of message data, in which case message is not stored. Empty reply removes override.
+func (s *Server) SetReply(command string, reply string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if reply == "" {
+		delete(s.replies, strings.ToUpper(command))
+
+		return
+	}
+
+	s.replies[strings.ToUpper(command)] = reply
+}
+
+// reply returns configured reply to command line, preferring the longest matching command.
+func (s *Server) reply(line string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line = strings.ToUpper(line)
+	matched, found := "", false
+
+	for command := range s.replies {
+		if strings.HasPrefix(line, command) && len(command) >= len(matched) {
+			matched, found = command, true
+		}
+	}
+
+	return s.replies[matched], found
+}
+
+// SetDelay makes server wait for delay before processing commands starting with command (case insensitive),
+// e.g. to test client timeouts. Command "." delays reply to the end of message data. Zero delay removes it.
+func (s *Server) SetDelay(command string, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if delay <= 0 {
+		delete(s.delays, strings.ToUpper(command))
+
+		return
+	}
+
+	s.delays[strings.ToUpper(command)] = delay
+}
+
+// delay waits for delay set for command line, preferring the longest matching command.
+// It returns early once server is closed.
+func (s *Server) delay(line string) {
+	s.mu.Lock()
+
+	line = strings.ToUpper(line)
+	matched, delay := "", time.Duration(0)
+
+	for command, d := range s.delays {
+		if strings.HasPrefix(line, command) && len(command) >= len(matched) {
+			matched, delay = command, d
+		}
+	}
+
+	s.mu.Unlock()
+
+	if delay == 0 {
+		return
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-s.closed:
+	}
+}
+
+// Envelopes returns received envelopes in receive order.
+func (s *Server) Envelopes() []Envelope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	envelopes := make([]Envelope, 0, len(s.envelopes))
+	for _, e := range s.envelopes {
+		envelopes = append(envelopes, e.clone())
+	}
+
+	return envelopes
+}
+
+// Wait waits until at least n envelopes are received and returns them.
+func (s *Server) Wait(ctx context.Context, n int) ([]Envelope, error) {
+	for {
+		s.mu.Lock()
+		count, changed := len(s.envelopes), s.changed
+		s.mu.Unlock()
+
+		if count >= n {
+			return s.Envelopes(), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return s.Envelopes(), fmt.Errorf("smtptest wait for %d envelopes error, got %d: %w", n, count, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Reset removes received envelopes, configured replies and delays.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.envelopes = nil
+	s.replies = make(map[string]string)
+	s.delays = make(map[string]time.Duration)
+}
+
+// CloseConnections closes open client connections without stopping server, e.g. to test client reconnects.
+func (s *Server) CloseConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+}
+
+// OpenConnections returns number of open client connections.
+func (s *Server) OpenConnections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.conns)
+}
+
+// Close stops listening, closes open connections and waits for their handlers to return.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+
+	s.closeOnce.Do(func() { close(s.closed) })
+	s.CloseConnections()
+
+	s.wg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("smtptest server close error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) store(e Envelope) {
+	s.mu.Lock()
+	s.envelopes = append(s.envelopes, e.clone())
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.mu.Unlock()
+
+	if s.cfg.OnReceive != nil {
+		s.cfg.OnReceive(e.clone())
+	}
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+
+		go func() {
+			defer s.wg.Done()
+
+			newSession(s, conn).serve()
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+
+			_ = conn.Close()
+		}()
+	}
+}
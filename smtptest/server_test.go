@@ -0,0 +1,330 @@
+package smtptest_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/smtp"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/spacetab-io/mails-go/smtptest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testData = "From: from@spacetab.io\r\nTo: to@spacetab.io\r\nSubject: Test email\r\n\r\ntest email content\r\n.dot line\r\n"
+
+type loginAuth struct {
+	username, password string
+}
+
+func (a loginAuth) Start(_ *smtp.ServerInfo) (string, []byte, error) {
+	return "LOGIN", nil, nil
+}
+
+func (a loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	switch {
+	case !more:
+		return nil, nil
+	case string(fromServer) == "Username:":
+		return []byte(a.username), nil
+	default:
+		return []byte(a.password), nil
+	}
+}
+
+// send sends test data from from@spacetab.io to rcpts, upgrading connection with tlsConfig if it is set.
+func send(s *smtptest.Server, tlsConfig *tls.Config, auth smtp.Auth, rcpts ...string) error {
+	c, err := smtp.Dial(s.Addr().String())
+	if err != nil {
+		return err
+	}
+
+	defer c.Close()
+
+	if tlsConfig != nil {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if auth != nil {
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail("from@spacetab.io"); err != nil {
+		return err
+	}
+
+	for _, rcpt := range rcpts {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte(testData)); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func replyCode(err error) int {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) {
+		return tpErr.Code
+	}
+
+	return 0
+}
+
+func TestServer(t *testing.T) {
+	users := map[string]string{"user": "secret"}
+
+	type expStruct struct {
+		code     int
+		username string
+		tls      bool
+	}
+
+	type testCase struct {
+		name   string
+		cfg    smtptest.Config
+		useTLS bool
+		auth   smtp.Auth
+		exp    expStruct
+	}
+
+	tcs := []testCase{
+		{name: "plain connection", exp: expStruct{}},
+		{name: "starttls", useTLS: true, exp: expStruct{tls: true}},
+		{
+			name: "auth plain",
+			cfg:  smtptest.Config{Users: users},
+			auth: smtp.PlainAuth("", "user", "secret", "127.0.0.1"),
+			exp:  expStruct{username: "user"},
+		},
+		{
+			name: "auth login",
+			cfg:  smtptest.Config{Users: users},
+			auth: loginAuth{username: "user", password: "secret"},
+			exp:  expStruct{username: "user"},
+		},
+		{
+			name: "auth cram-md5",
+			cfg:  smtptest.Config{Users: users},
+			auth: smtp.CRAMMD5Auth("user", "secret"),
+			exp:  expStruct{username: "user"},
+		},
+		{
+			name:   "auth after starttls",
+			cfg:    smtptest.Config{Users: users, RequireTLS: true},
+			useTLS: true,
+			auth:   smtp.PlainAuth("", "user", "secret", "127.0.0.1"),
+			exp:    expStruct{username: "user", tls: true},
+		},
+		{
+			name: "wrong password",
+			cfg:  smtptest.Config{Users: users},
+			auth: smtp.CRAMMD5Auth("user", "wrong"),
+			exp:  expStruct{code: 535},
+		},
+		{name: "auth required", cfg: smtptest.Config{Users: users}, exp: expStruct{code: 530}},
+		{name: "tls required", cfg: smtptest.Config{RequireTLS: true}, exp: expStruct{code: 530}},
+		{name: "message too big", cfg: smtptest.Config{MaxSize: 10}, exp: expStruct{code: 552}},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := smtptest.Start(t, tc.cfg)
+
+			var tlsConfig *tls.Config
+			if tc.useTLS {
+				tlsConfig = s.ClientTLSConfig()
+			}
+
+			err := send(s, tlsConfig, tc.auth, "to@spacetab.io", "cc@spacetab.io")
+
+			if tc.exp.code != 0 {
+				assert.Equal(t, tc.exp.code, replyCode(err), err)
+				assert.Empty(t, s.Envelopes())
+
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+
+			envelopes := s.Envelopes()
+			if !assert.Len(t, envelopes, 1) {
+				t.FailNow()
+			}
+
+			assert.Equal(t, "localhost", envelopes[0].Helo)
+			assert.Equal(t, tc.exp.username, envelopes[0].Username)
+			assert.Equal(t, tc.exp.tls, envelopes[0].TLS)
+			assert.Equal(t, "from@spacetab.io", envelopes[0].From)
+			assert.Equal(t, []string{"to@spacetab.io", "cc@spacetab.io"}, envelopes[0].To)
+			assert.Equal(t, testData, string(envelopes[0].Data))
+		})
+	}
+}
+
+func TestServer_SetReply(t *testing.T) {
+	type testCase struct {
+		name    string
+		command string
+		reply   string
+		exp     int
+	}
+
+	tcs := []testCase{
+		{name: "mail", command: "MAIL", reply: "421 4.3.2 service not available", exp: 421},
+		{name: "recipient", command: "RCPT TO:<cc@spacetab.io>", reply: "550 5.1.1 no such user", exp: 550},
+		{name: "data", command: "DATA", reply: "554 5.7.1 rejected", exp: 554},
+		{name: "end of data", command: ".", reply: "451 4.3.0 try again later", exp: 451},
+	}
+
+	t.Parallel()
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := smtptest.Start(t, smtptest.Config{})
+			s.SetReply(tc.command, tc.reply)
+
+			assert.Equal(t, tc.exp, replyCode(send(s, nil, nil, "to@spacetab.io", "cc@spacetab.io")))
+			assert.Empty(t, s.Envelopes())
+
+			s.SetReply(tc.command, "")
+
+			assert.NoError(t, send(s, nil, nil, "to@spacetab.io", "cc@spacetab.io"))
+			assert.Len(t, s.Envelopes(), 1)
+		})
+	}
+}
+
+func TestServer_Wait(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan smtptest.Envelope, 1)
+	s := smtptest.Start(t, smtptest.Config{OnReceive: func(e smtptest.Envelope) { received <- e }})
+
+	go func() { _ = send(s, nil, nil, "to@spacetab.io") }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	envelopes, err := s.Wait(ctx, 1)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, envelopes[0], <-received)
+
+	msg, err := envelopes[0].Message()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "Test email", msg.GetSubject())
+	assert.Equal(t, "test email content\n.dot line\n", string(msg.GetBody()))
+
+	s.Reset()
+	assert.Empty(t, s.Envelopes())
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = s.Wait(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServer_Close(t *testing.T) {
+	t.Parallel()
+
+	s, err := smtptest.NewServer(smtptest.Config{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// idle connection must not block server close
+	c, err := smtp.Dial(s.Addr().String())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	defer c.Close()
+
+	assert.NoError(t, s.Close())
+
+	_, err = smtp.Dial(s.Addr().String())
+	assert.Error(t, err)
+}
+
+func TestServer_CloseConnections(t *testing.T) {
+	t.Parallel()
+
+	s := smtptest.Start(t, smtptest.Config{})
+
+	c, err := smtp.Dial(s.Addr().String())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	defer c.Close()
+
+	if !assert.NoError(t, c.Noop()) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, 1, s.OpenConnections())
+
+	s.CloseConnections()
+
+	assert.Error(t, c.Noop())
+	assert.Eventually(t, func() bool { return s.OpenConnections() == 0 }, time.Second, 10*time.Millisecond)
+	assert.NoError(t, send(s, nil, nil, "to@spacetab.io"))
+}
+
+func TestServer_SetDelay(t *testing.T) {
+	t.Parallel()
+
+	s := smtptest.Start(t, smtptest.Config{})
+	s.SetDelay(".", 50*time.Millisecond)
+
+	start := time.Now()
+
+	assert.NoError(t, send(s, nil, nil, "to@spacetab.io"))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// delayed reply doesn't block server close
+	s.SetDelay("MAIL", time.Hour)
+
+	sent := make(chan error, 1)
+	go func() { sent <- send(s, nil, nil, "to@spacetab.io") }()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, s.Close())
+	assert.Error(t, <-sent)
+}
@@ -0,0 +1,479 @@
+package smtptest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5" // nolint: gosec
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	authPlain   = "PLAIN"
+	authLogin   = "LOGIN"
+	authCRAMMD5 = "CRAM-MD5"
+)
+
+// session is a state of a single client connection.
+type session struct {
+	s    *Server
+	conn net.Conn
+	r    *bufio.Reader
+
+	helo     string
+	username string
+	tls      bool
+	from     string
+	hasFrom  bool
+	to       []string
+}
+
+func newSession(s *Server, conn net.Conn) *session {
+	return &session{s: s, conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (ss *session) serve() {
+	ss.reply("220 %s ESMTP smtptest", ss.s.cfg.Hostname)
+
+	for {
+		line, err := ss.readLine()
+		if err != nil {
+			return
+		}
+
+		ss.s.delay(line)
+
+		if r, ok := ss.s.reply(line); ok {
+			ss.reply("%s", r)
+
+			continue
+		}
+
+		verb, arg := line, ""
+		if i := strings.IndexByte(line, ' '); i != -1 {
+			verb, arg = line[:i], strings.TrimSpace(line[i+1:])
+		}
+
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			ss.hello(arg, false)
+		case "EHLO":
+			ss.hello(arg, true)
+		case "STARTTLS":
+			if !ss.startTLS() {
+				return
+			}
+		case "AUTH":
+			ss.auth(arg)
+		case "MAIL":
+			ss.mail(arg)
+		case "RCPT":
+			ss.rcpt(arg)
+		case "DATA":
+			if !ss.data() {
+				return
+			}
+		case "RSET":
+			ss.resetTransaction()
+			ss.reply("250 2.0.0 OK")
+		case "NOOP":
+			ss.reply("250 2.0.0 OK")
+		case "VRFY":
+			ss.reply("252 2.5.0 cannot verify user")
+		case "QUIT":
+			ss.reply("221 2.0.0 %s closing connection", ss.s.cfg.Hostname)
+
+			return
+		default:
+			ss.reply("502 5.5.2 command not implemented")
+		}
+	}
+}
+
+func (ss *session) reply(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(ss.conn, format+"\r\n", args...)
+}
+
+func (ss *session) readLine() (string, error) {
+	line, err := ss.r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("read line error: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (ss *session) authRequired() bool {
+	return len(ss.s.cfg.Users) != 0
+}
+
+func (ss *session) tlsAvailable() bool {
+	return !ss.tls && !ss.s.cfg.DisableSTARTTLS && ss.s.tlsConfig != nil
+}
+
+func (ss *session) hello(arg string, extended bool) {
+	if arg == "" {
+		ss.reply("501 5.5.4 domain required")
+
+		return
+	}
+
+	ss.helo = arg
+	ss.resetTransaction()
+
+	if !extended {
+		ss.reply("250 %s", ss.s.cfg.Hostname)
+
+		return
+	}
+
+	lines := []string{ss.s.cfg.Hostname, "8BITMIME", "PIPELINING"}
+
+	if ss.s.cfg.MaxSize > 0 {
+		lines = append(lines, fmt.Sprintf("SIZE %d", ss.s.cfg.MaxSize))
+	}
+
+	if ss.tlsAvailable() {
+		lines = append(lines, "STARTTLS")
+	}
+
+	if ss.authRequired() && (ss.tls || !ss.s.cfg.RequireTLS) {
+		lines = append(lines, "AUTH "+strings.Join([]string{authPlain, authLogin, authCRAMMD5}, " "))
+	}
+
+	for i, l := range lines {
+		if i == len(lines)-1 {
+			ss.reply("250 %s", l)
+		} else {
+			ss.reply("250-%s", l)
+		}
+	}
+}
+
+// startTLS upgrades connection, returning false if connection is broken.
+func (ss *session) startTLS() bool {
+	if !ss.tlsAvailable() {
+		ss.reply("502 5.5.1 STARTTLS not available")
+
+		return true
+	}
+
+	ss.reply("220 2.0.0 ready to start TLS")
+
+	conn := tls.Server(ss.conn, ss.s.tlsConfig)
+	if err := conn.Handshake(); err != nil {
+		return false
+	}
+
+	// client starts over after STARTTLS (RFC 3207, section 4.2)
+	ss.conn, ss.r, ss.tls = conn, bufio.NewReader(conn), true
+	ss.helo, ss.username = "", ""
+	ss.resetTransaction()
+
+	return true
+}
+
+func (ss *session) auth(arg string) {
+	switch {
+	case !ss.authRequired():
+		ss.reply("502 5.5.1 AUTH not available")
+
+		return
+	case ss.s.cfg.RequireTLS && !ss.tls:
+		ss.reply("530 5.7.0 must issue a STARTTLS command first")
+
+		return
+	case ss.username != "":
+		ss.reply("503 5.5.1 already authenticated")
+
+		return
+	}
+
+	mechanism, initial := arg, ""
+	if i := strings.IndexByte(arg, ' '); i != -1 {
+		mechanism, initial = arg[:i], strings.TrimSpace(arg[i+1:])
+	}
+
+	var (
+		username string
+		ok       bool
+		err      error
+	)
+
+	switch strings.ToUpper(mechanism) {
+	case authPlain:
+		username, ok, err = ss.authPlain(initial)
+	case authLogin:
+		username, ok, err = ss.authLogin(initial)
+	case authCRAMMD5:
+		username, ok, err = ss.authCRAMMD5()
+	default:
+		ss.reply("504 5.5.4 unrecognized authentication type")
+
+		return
+	}
+
+	switch {
+	case err != nil:
+		ss.reply("501 5.5.2 %s", err)
+	case !ok:
+		ss.reply("535 5.7.8 authentication credentials invalid")
+	default:
+		ss.username = username
+		ss.reply("235 2.7.0 authentication successful")
+	}
+}
+
+// challenge sends base64 encoded challenge and returns decoded client response.
+func (ss *session) challenge(challenge string) ([]byte, error) {
+	ss.reply("334 %s", base64.StdEncoding.EncodeToString([]byte(challenge)))
+
+	line, err := ss.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResponse(line)
+}
+
+func decodeResponse(line string) ([]byte, error) {
+	if line == "*" {
+		return nil, fmt.Errorf("authentication cancelled") // nolint: goerr113
+	}
+
+	resp, err := base64.StdEncoding.DecodeString(line)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (ss *session) authPlain(initial string) (string, bool, error) {
+	var (
+		resp []byte
+		err  error
+	)
+
+	if initial == "" {
+		resp, err = ss.challenge("")
+	} else {
+		resp, err = decodeResponse(initial)
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	// response is authzid NUL authcid NUL password (RFC 4616)
+	parts := bytes.Split(resp, []byte{0})
+	if len(parts) != 3 { // nolint: gomnd
+		return "", false, fmt.Errorf("invalid PLAIN response") // nolint: goerr113
+	}
+
+	username := string(parts[1])
+
+	return username, ss.checkPassword(username, string(parts[2])), nil
+}
+
+func (ss *session) authLogin(initial string) (string, bool, error) {
+	var (
+		username []byte
+		err      error
+	)
+
+	if initial == "" {
+		username, err = ss.challenge("Username:")
+	} else {
+		username, err = decodeResponse(initial)
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	password, err := ss.challenge("Password:")
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(username), ss.checkPassword(string(username), string(password)), nil
+}
+
+func (ss *session) authCRAMMD5() (string, bool, error) {
+	challenge := fmt.Sprintf("<%d.%d@%s>", os.Getpid(), time.Now().UnixNano(), ss.s.cfg.Hostname)
+
+	resp, err := ss.challenge(challenge)
+	if err != nil {
+		return "", false, err
+	}
+
+	// response is username SP hex digest of hmac-md5 keyed with password (RFC 2195)
+	i := bytes.LastIndexByte(resp, ' ')
+	if i == -1 {
+		return "", false, fmt.Errorf("invalid CRAM-MD5 response") // nolint: goerr113
+	}
+
+	username, digest := string(resp[:i]), resp[i+1:]
+
+	password, ok := ss.s.cfg.Users[username]
+	if !ok {
+		return username, false, nil
+	}
+
+	d := hmac.New(md5.New, []byte(password))
+	_, _ = d.Write([]byte(challenge))
+
+	return username, hmac.Equal([]byte(hex.EncodeToString(d.Sum(nil))), digest), nil
+}
+
+func (ss *session) checkPassword(username string, password string) bool {
+	expected, ok := ss.s.cfg.Users[username]
+
+	return ok && hmac.Equal([]byte(expected), []byte(password))
+}
+
+func (ss *session) mail(arg string) {
+	switch {
+	case ss.helo == "":
+		ss.reply("503 5.5.1 send EHLO first")
+
+		return
+	case ss.s.cfg.RequireTLS && !ss.tls:
+		ss.reply("530 5.7.0 must issue a STARTTLS command first")
+
+		return
+	case ss.authRequired() && ss.username == "":
+		ss.reply("530 5.7.0 authentication required")
+
+		return
+	case ss.hasFrom:
+		ss.reply("503 5.5.1 nested MAIL command")
+
+		return
+	}
+
+	from, ok := parsePath(arg, "FROM:")
+	if !ok {
+		ss.reply("501 5.5.4 syntax: MAIL FROM:<address>")
+
+		return
+	}
+
+	ss.from, ss.hasFrom = from, true
+	ss.reply("250 2.1.0 OK")
+}
+
+func (ss *session) rcpt(arg string) {
+	if !ss.hasFrom {
+		ss.reply("503 5.5.1 need MAIL command")
+
+		return
+	}
+
+	to, ok := parsePath(arg, "TO:")
+	if !ok || to == "" {
+		ss.reply("501 5.5.4 syntax: RCPT TO:<address>")
+
+		return
+	}
+
+	ss.to = append(ss.to, to)
+	ss.reply("250 2.1.5 OK")
+}
+
+// data reads message, returning false if connection is broken.
+func (ss *session) data() bool {
+	if len(ss.to) == 0 {
+		ss.reply("503 5.5.1 need RCPT command")
+
+		return true
+	}
+
+	ss.reply("354 end data with <CR><LF>.<CR><LF>")
+
+	data := &bytes.Buffer{}
+	tooBig := false
+
+	for {
+		line, err := ss.r.ReadString('\n')
+		if err != nil {
+			return false
+		}
+
+		if line == ".\r\n" || line == ".\n" {
+			break
+		}
+
+		// transparency procedure (RFC 5321, section 4.5.2)
+		line = strings.TrimPrefix(line, ".")
+
+		if ss.s.cfg.MaxSize > 0 && data.Len()+len(line) > ss.s.cfg.MaxSize {
+			tooBig = true
+
+			continue
+		}
+
+		data.WriteString(line)
+	}
+
+	defer ss.resetTransaction()
+
+	ss.s.delay(".")
+
+	if r, ok := ss.s.reply("."); ok {
+		ss.reply("%s", r)
+
+		return true
+	}
+
+	if tooBig {
+		ss.reply("552 5.3.4 message size exceeds fixed limit")
+
+		return true
+	}
+
+	ss.s.store(Envelope{
+		RemoteAddr: ss.conn.RemoteAddr().String(),
+		Helo:       ss.helo,
+		Username:   ss.username,
+		TLS:        ss.tls,
+		From:       ss.from,
+		To:         ss.to,
+		Data:       data.Bytes(),
+		ReceivedAt: time.Now(),
+	})
+
+	ss.reply("250 2.0.0 OK queued")
+
+	return true
+}
+
+func (ss *session) resetTransaction() {
+	ss.from, ss.hasFrom, ss.to = "", false, nil
+}
+
+// parsePath parses "FROM:<address> [params]" argument, returning address without angle brackets.
+func parsePath(arg string, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	path := strings.TrimSpace(arg[len(prefix):])
+	if i := strings.IndexByte(path, ' '); i != -1 {
+		path = path[:i]
+	}
+
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", false
+	}
+
+	return path[1 : len(path)-1], true
+}